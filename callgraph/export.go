@@ -0,0 +1,216 @@
+// Package callgraph converts callgraphs to formats understood by graph
+// visualization tools.
+package callgraph
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	"github.com/harness/ti-client/types"
+)
+
+// Filter restricts the part of a callgraph which is exported.
+type Filter struct {
+	// Packages keeps only nodes whose package has one of these prefixes.
+	Packages []string
+	// Root, if set, keeps only nodes reachable from the node with this id
+	// (typically a test) within Depth edges.
+	Root *int
+	// Depth limits the distance from Root. Zero means unlimited.
+	Depth int
+}
+
+// Apply returns the subgraph of g selected by the filter.
+func (f Filter) Apply(g types.GetVgResp) types.GetVgResp {
+	keep := make(map[int]bool, len(g.Nodes))
+	for _, n := range g.Nodes {
+		keep[n.Id] = f.matchesPackage(n.Package)
+	}
+	if f.Root != nil {
+		reachable := f.reachable(g)
+		for id := range keep {
+			keep[id] = keep[id] && reachable[id]
+		}
+	}
+
+	var out types.GetVgResp
+	for _, n := range g.Nodes {
+		if keep[n.Id] {
+			out.Nodes = append(out.Nodes, n)
+		}
+	}
+	for _, e := range g.Edges {
+		if !keep[e.From] {
+			continue
+		}
+		var to []int
+		for _, id := range e.To {
+			if keep[id] {
+				to = append(to, id)
+			}
+		}
+		if len(to) > 0 {
+			out.Edges = append(out.Edges, types.VisMapping{From: e.From, To: to})
+		}
+	}
+	return out
+}
+
+func (f Filter) matchesPackage(pkg string) bool {
+	if len(f.Packages) == 0 {
+		return true
+	}
+	for _, p := range f.Packages {
+		if strings.HasPrefix(pkg, p) {
+			return true
+		}
+	}
+	return false
+}
+
+// reachable returns the nodes within Depth edges of Root.
+func (f Filter) reachable(g types.GetVgResp) map[int]bool {
+	adj := make(map[int][]int)
+	for _, e := range g.Edges {
+		adj[e.From] = append(adj[e.From], e.To...)
+	}
+	seen := map[int]bool{*f.Root: true}
+	frontier := []int{*f.Root}
+	for depth := 0; len(frontier) > 0 && (f.Depth == 0 || depth < f.Depth); depth++ {
+		var next []int
+		for _, id := range frontier {
+			for _, to := range adj[id] {
+				if !seen[to] {
+					seen[to] = true
+					next = append(next, to)
+				}
+			}
+		}
+		frontier = next
+	}
+	return seen
+}
+
+// label returns a human readable label of a node.
+func label(n types.VisNode) string {
+	name := n.Class
+	if n.Package != "" {
+		name = n.Package + "." + n.Class
+	}
+	if name == "" {
+		name = n.File
+	}
+	return name
+}
+
+// WriteDOT writes the filtered callgraph g to w in Graphviz DOT format.
+func WriteDOT(w io.Writer, g types.GetVgResp, f Filter) error {
+	g = f.Apply(g)
+	var b strings.Builder
+	b.WriteString("digraph callgraph {\n")
+	for _, n := range g.Nodes {
+		attrs := []string{fmt.Sprintf("label=%q", label(n))}
+		if n.Type == "test" {
+			attrs = append(attrs, "shape=box")
+		}
+		if n.Important || n.Root {
+			attrs = append(attrs, "style=bold")
+		}
+		fmt.Fprintf(&b, "  n%d [%s];\n", n.Id, strings.Join(attrs, ", "))
+	}
+	for _, e := range g.Edges {
+		to := append([]int(nil), e.To...)
+		sort.Ints(to)
+		for _, id := range to {
+			fmt.Fprintf(&b, "  n%d -> n%d;\n", e.From, id)
+		}
+	}
+	b.WriteString("}\n")
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+type graphML struct {
+	XMLName xml.Name     `xml:"graphml"`
+	XMLNS   string       `xml:"xmlns,attr"`
+	Keys    []graphMLKey `xml:"key"`
+	Graph   graphMLGraph `xml:"graph"`
+}
+
+type graphMLKey struct {
+	ID   string `xml:"id,attr"`
+	For  string `xml:"for,attr"`
+	Name string `xml:"attr.name,attr"`
+	Type string `xml:"attr.type,attr"`
+}
+
+type graphMLGraph struct {
+	ID          string        `xml:"id,attr"`
+	EdgeDefault string        `xml:"edgedefault,attr"`
+	Nodes       []graphMLNode `xml:"node"`
+	Edges       []graphMLEdge `xml:"edge"`
+}
+
+type graphMLNode struct {
+	ID   string        `xml:"id,attr"`
+	Data []graphMLData `xml:"data"`
+}
+
+type graphMLEdge struct {
+	Source string `xml:"source,attr"`
+	Target string `xml:"target,attr"`
+}
+
+type graphMLData struct {
+	Key   string `xml:"key,attr"`
+	Value string `xml:",chardata"`
+}
+
+// WriteGraphML writes the filtered callgraph g to w in GraphML format.
+func WriteGraphML(w io.Writer, g types.GetVgResp, f Filter) error {
+	g = f.Apply(g)
+	doc := graphML{
+		XMLNS: "http://graphml.graphdrawing.org/xmlns",
+		Keys: []graphMLKey{
+			{ID: "label", For: "node", Name: "label", Type: "string"},
+			{ID: "package", For: "node", Name: "package", Type: "string"},
+			{ID: "class", For: "node", Name: "class", Type: "string"},
+			{ID: "file", For: "node", Name: "file", Type: "string"},
+			{ID: "type", For: "node", Name: "type", Type: "string"},
+		},
+		Graph: graphMLGraph{ID: "callgraph", EdgeDefault: "directed"},
+	}
+	for _, n := range g.Nodes {
+		doc.Graph.Nodes = append(doc.Graph.Nodes, graphMLNode{
+			ID: fmt.Sprintf("n%d", n.Id),
+			Data: []graphMLData{
+				{Key: "label", Value: label(n)},
+				{Key: "package", Value: n.Package},
+				{Key: "class", Value: n.Class},
+				{Key: "file", Value: n.File},
+				{Key: "type", Value: n.Type},
+			},
+		})
+	}
+	for _, e := range g.Edges {
+		for _, id := range e.To {
+			doc.Graph.Edges = append(doc.Graph.Edges, graphMLEdge{
+				Source: fmt.Sprintf("n%d", e.From),
+				Target: fmt.Sprintf("n%d", id),
+			})
+		}
+	}
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		return err
+	}
+	enc := xml.NewEncoder(w)
+	enc.Indent("", "  ")
+	if err := enc.Encode(doc); err != nil {
+		return err
+	}
+	_, err := io.WriteString(w, "\n")
+	return err
+}
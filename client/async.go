@@ -0,0 +1,188 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/harness/ti-client/types"
+)
+
+var (
+	// ErrQueueFull is returned by AsyncClient when its queue is full.
+	ErrQueueFull = errors.New("ti async queue is full")
+	// ErrClientClosed is returned for calls made after Close.
+	ErrClientClosed = errors.New("ti client is closed")
+)
+
+// AsyncClient wraps a Client so that Write and WriteSavings return as soon
+// as the call is queued. Queued calls are sent by background workers; Flush
+// waits for them and reports their errors. The other methods of Client are
+// passed through to the wrapped client, whose optional interfaces are
+// reached through the Client field.
+type AsyncClient struct {
+	Client
+
+	queue   chan func() error
+	workers sync.WaitGroup
+	// stop cancels the queued calls once Close gave up waiting for them.
+	stop chan struct{}
+
+	mu      sync.Mutex
+	closed  bool
+	pending int
+	idle    []chan struct{}
+	errs    []error
+}
+
+// NewAsyncClient returns an AsyncClient queuing up to queueSize calls and
+// sending them on the given number of background workers.
+func NewAsyncClient(c Client, queueSize, workers int) *AsyncClient {
+	if workers < 1 {
+		workers = 1
+	}
+	a := &AsyncClient{Client: c, queue: make(chan func() error, queueSize), stop: make(chan struct{})}
+	for i := 0; i < workers; i++ {
+		a.workers.Add(1)
+		go a.work()
+	}
+	return a
+}
+
+// Write queues test cases to be written to the TI server.
+func (a *AsyncClient) Write(ctx context.Context, step, report string, tests []*types.TestCase) error {
+	ctx = a.detach(ctx)
+	return a.enqueue(func() error {
+		return a.Client.Write(ctx, step, report, tests)
+	})
+}
+
+// WriteSavings queues time savings to be written to the TI server.
+func (a *AsyncClient) WriteSavings(ctx context.Context, stepID string, featureName types.SavingsFeature, featureState types.IntelligenceExecutionState, timeTakenMs int64, savingsRequest types.SavingsRequest) error {
+	ctx = a.detach(ctx)
+	return a.enqueue(func() error {
+		return a.Client.WriteSavings(ctx, stepID, featureName, featureState, timeTakenMs, savingsRequest)
+	})
+}
+
+// Flush waits until all queued calls have been sent, or ctx is done, and
+// returns the errors of the calls completed since the previous Flush.
+func (a *AsyncClient) Flush(ctx context.Context) error {
+	a.mu.Lock()
+	if a.pending > 0 {
+		ch := make(chan struct{})
+		a.idle = append(a.idle, ch)
+		a.mu.Unlock()
+		select {
+		case <-ch:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+		a.mu.Lock()
+	}
+	errs := a.errs
+	a.errs = nil
+	a.mu.Unlock()
+	return errors.Join(errs...)
+}
+
+// Close stops accepting new calls, flushes the queue until ctx is done and
+// stops the background workers. Calls still queued or in flight when ctx
+// is done are canceled. If the wrapped client can be closed, such as an
+// HTTPClient, it is closed as well.
+func (a *AsyncClient) Close(ctx context.Context) error {
+	a.mu.Lock()
+	if a.closed {
+		a.mu.Unlock()
+		return nil
+	}
+	a.closed = true
+	close(a.queue)
+	a.mu.Unlock()
+
+	err := a.Flush(ctx)
+	if ctx.Err() != nil {
+		close(a.stop)
+	}
+	// Once the queue is flushed, or ctx is done, closing the wrapped client
+	// aborts the retries of the calls still in flight, so it must not wait
+	// for the workers.
+	if c, ok := a.Client.(interface{ Close(context.Context) error }); ok {
+		err = errors.Join(err, c.Close(ctx))
+	}
+	stopped := make(chan struct{})
+	go func() {
+		a.workers.Wait()
+		close(stopped)
+	}()
+	select {
+	case <-stopped:
+	case <-ctx.Done():
+		select {
+		case <-a.stop:
+		default:
+			close(a.stop)
+		}
+	}
+	return err
+}
+
+func (a *AsyncClient) enqueue(call func() error) error {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	if a.closed {
+		return ErrClientClosed
+	}
+	select {
+	case a.queue <- call:
+		a.pending++
+		return nil
+	default:
+		return ErrQueueFull
+	}
+}
+
+func (a *AsyncClient) work() {
+	defer a.workers.Done()
+	for call := range a.queue {
+		err := call()
+		a.mu.Lock()
+		if err != nil {
+			a.errs = append(a.errs, err)
+		}
+		a.pending--
+		if a.pending == 0 {
+			for _, ch := range a.idle {
+				close(ch)
+			}
+			a.idle = nil
+		}
+		a.mu.Unlock()
+	}
+}
+
+// detachedContext keeps the values of its parent but not its deadline or
+// cancellation, so queued calls outlive the caller's context. It is done
+// once Close gave up waiting for the queued calls.
+type detachedContext struct {
+	parent context.Context
+	done   <-chan struct{}
+}
+
+func (a *AsyncClient) detach(ctx context.Context) context.Context {
+	return detachedContext{parent: ctx, done: a.stop}
+}
+
+func (d detachedContext) Deadline() (time.Time, bool)       { return time.Time{}, false }
+func (d detachedContext) Done() <-chan struct{}             { return d.done }
+func (d detachedContext) Value(key interface{}) interface{} { return d.parent.Value(key) }
+
+func (d detachedContext) Err() error {
+	select {
+	case <-d.done:
+		return context.Canceled
+	default:
+		return nil
+	}
+}
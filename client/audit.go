@@ -0,0 +1,105 @@
+package client
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"os"
+	"sync"
+	"time"
+)
+
+// AuditRecord is a single line of the audit log describing a mutating call.
+type AuditRecord struct {
+	Time          time.Time `json:"time"`
+	Operation     string    `json:"operation"`
+	Endpoint      string    `json:"endpoint"`
+	CorrelationID string    `json:"correlation_id,omitempty"`
+	AccountID     string    `json:"account_id"`
+	OrgID         string    `json:"org_id"`
+	ProjectID     string    `json:"project_id"`
+	PipelineID    string    `json:"pipeline_id"`
+	BuildID       string    `json:"build_id"`
+	StageID       string    `json:"stage_id"`
+	StepID        string    `json:"step_id,omitempty"`
+	Repo          string    `json:"repo,omitempty"`
+	Sha           string    `json:"sha,omitempty"`
+	PayloadSHA256 string    `json:"payload_sha256"`
+	PayloadBytes  int       `json:"payload_bytes"`
+	Result        string    `json:"result"`
+	Error         string    `json:"error,omitempty"`
+}
+
+// WithAuditLog appends a JSON line to the file at path for every mutating
+// call (Write, WriteManualResults, WriteBenchmarks, UploadCg, WriteSavings,
+// ReprocessReport, muting rule and webhook changes) made by the client.
+func WithAuditLog(path string) Option {
+	return func(c *HTTPClient) {
+		c.auditLog = &auditLog{path: path}
+	}
+}
+
+// auditLog serializes appends to the audit file.
+type auditLog struct {
+	mu   sync.Mutex
+	path string
+}
+
+func (a *auditLog) append(rec *AuditRecord) error {
+	line, err := json.Marshal(rec)
+	if err != nil {
+		return err
+	}
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	f, err := os.OpenFile(a.path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o600)
+	if err != nil {
+		return err
+	}
+	if _, err := f.Write(append(line, '\n')); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+// audit records the outcome of a mutating call if audit logging is enabled.
+func (c *HTTPClient) audit(ctx context.Context, operation, path, stepID string, payload interface{}, callErr error) {
+	if c.auditLog == nil {
+		return
+	}
+	var data []byte
+	switch p := payload.(type) {
+	case []byte:
+		data = p
+	default:
+		data, _ = json.Marshal(p)
+	}
+	digest := sha256.Sum256(data)
+	rec := &AuditRecord{
+		Time:          time.Now().UTC(),
+		Operation:     operation,
+		Endpoint:      SanitizeURL(path),
+		CorrelationID: CorrelationID(ctx),
+		AccountID:     c.AccountID,
+		OrgID:         c.OrgID,
+		ProjectID:     c.ProjectID,
+		PipelineID:    c.PipelineID,
+		BuildID:       c.BuildID,
+		StageID:       c.StageID,
+		StepID:        stepID,
+		Repo:          c.repo(ctx),
+		Sha:           c.sha(ctx),
+		PayloadSHA256: hex.EncodeToString(digest[:]),
+		PayloadBytes:  len(data),
+		Result:        "success",
+	}
+	if callErr != nil {
+		rec.Result = "failure"
+		rec.Error = callErr.Error()
+	}
+	if err := c.auditLog.append(rec); err != nil {
+		c.logger().Warnf("could not write audit record to %s: %s", c.auditLog.path, err)
+	}
+}
@@ -0,0 +1,146 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/harness/ti-client/types"
+)
+
+type maxAttemptsKey struct{}
+
+// WithMaxAttempts returns a copy of ctx limiting calls made with it to at
+// most n attempts, including the first one. Values below 1 are ignored.
+func WithMaxAttempts(ctx context.Context, n int) context.Context {
+	return context.WithValue(ctx, maxAttemptsKey{}, n)
+}
+
+// maxAttemptsFrom returns the attempt limit carried by ctx, or 0 if unset.
+func maxAttemptsFrom(ctx context.Context) int {
+	n, _ := ctx.Value(maxAttemptsKey{}).(int)
+	return n
+}
+
+// WithRetryBudget limits the retries made by the client across all of its
+// calls. The budget holds up to capacity retries and regains one retry every
+// refill interval, so that persistent server errors on many endpoints do not
+// add up to hours of retry sleeping.
+func WithRetryBudget(capacity int, refill time.Duration) Option {
+	return func(c *HTTPClient) {
+		c.retryBudget = newRetryBudget(capacity, refill)
+	}
+}
+
+// retryBudget is a token bucket of retries shared by all calls of a client.
+type retryBudget struct {
+	mu       sync.Mutex
+	tokens   float64
+	capacity float64
+	refill   time.Duration
+	last     time.Time
+}
+
+func newRetryBudget(capacity int, refill time.Duration) *retryBudget {
+	return &retryBudget{
+		tokens:   float64(capacity),
+		capacity: float64(capacity),
+		refill:   refill,
+		last:     time.Now(),
+	}
+}
+
+// take consumes a retry from the budget, reporting false if none is left.
+func (b *retryBudget) take() bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	now := time.Now()
+	if b.refill > 0 {
+		b.tokens += float64(now.Sub(b.last)) / float64(b.refill)
+		if b.tokens > b.capacity {
+			b.tokens = b.capacity
+		}
+	}
+	b.last = now
+	if b.tokens < 1 {
+		return false
+	}
+	b.tokens--
+	return true
+}
+
+// allowRetry reports whether another attempt may follow the given one.
+func (c *HTTPClient) allowRetry(ctx context.Context, attempt int) bool {
+	if max := maxAttemptsFrom(ctx); max > 0 && attempt >= max {
+		return false
+	}
+	if c.retryBudget != nil && !c.retryBudget.take() {
+		c.logger().Warnf("ti client retry budget exhausted, giving up after %d attempts", attempt)
+		return false
+	}
+	return true
+}
+
+// RetryFunc decides whether a failed attempt should be retried. It is only
+// consulted for attempts that returned an error; resp is nil
+// when no response was received. The backoff, attempt limit and retry budget
+// still bound the number of retries.
+type RetryFunc func(resp *http.Response, err error, attempt int) bool
+
+// WithShouldRetry replaces the default retry decision, which retries request
+// errors and, for endpoints that allow it, 5xx responses.
+func WithShouldRetry(fn RetryFunc) Option {
+	return func(c *HTTPClient) {
+		c.shouldRetry = fn
+	}
+}
+
+const (
+	defaultMLSelectTimeout = 5 * time.Minute
+	defaultMLSelectBudget  = 2 * time.Minute
+)
+
+// WithMLSelectLimits sets the overall timeout of MLSelectTests calls and the
+// maximum time spent backing off between their retries. Zero values keep the
+// defaults of 5 and 2 minutes.
+func WithMLSelectLimits(timeout, retryBudget time.Duration) Option {
+	return func(c *HTTPClient) {
+		c.mlSelectTimeout = timeout
+		c.mlSelectBudget = retryBudget
+	}
+}
+
+// mlSelectLimits returns the timeout and retry budget of MLSelectTests.
+func (c *HTTPClient) mlSelectLimits() (timeout, budget time.Duration) {
+	timeout, budget = c.mlSelectTimeout, c.mlSelectBudget
+	if timeout <= 0 {
+		timeout = defaultMLSelectTimeout
+	}
+	if budget <= 0 {
+		budget = defaultMLSelectBudget
+	}
+	return timeout, budget
+}
+
+// WithMinSelectionConfidence makes MLSelectTests select all the tests when
+// the aggregate confidence of the selection (see
+// SelectTestsResp.AggregateConfidence) is below threshold. Selections
+// without confidence are left as is.
+func WithMinSelectionConfidence(threshold float64) Option {
+	return func(c *HTTPClient) {
+		c.minConfidence = threshold
+	}
+}
+
+// applyConfidenceThreshold expands resp to all the tests when its confidence
+// is below the configured threshold.
+func (c *HTTPClient) applyConfidenceThreshold(resp *types.SelectTestsResp) {
+	if c.minConfidence <= 0 || resp.SelectAll {
+		return
+	}
+	if confidence, ok := resp.AggregateConfidence(); ok && confidence < c.minConfidence {
+		c.logger().Infof("selection confidence %.2f is below %.2f, running all tests", confidence, c.minConfidence)
+		resp.SelectAll = true
+	}
+}
@@ -0,0 +1,133 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/harness/ti-client/types"
+)
+
+// CgMigration converts a callgraph payload from one schema version to the
+// adjacent one.
+type CgMigration func(cg []byte) ([]byte, error)
+
+type cgMigrationKey struct {
+	from, to types.SchemaVersion
+}
+
+var (
+	cgMigrationsMu sync.RWMutex
+	cgMigrations   = map[cgMigrationKey]CgMigration{}
+)
+
+// RegisterCgMigration registers a converter of callgraph payloads between
+// two schema versions. Agents register converters for the formats they
+// produce so the client can talk to servers on older or newer schemas.
+func RegisterCgMigration(from, to types.SchemaVersion, fn CgMigration) {
+	cgMigrationsMu.Lock()
+	cgMigrations[cgMigrationKey{from, to}] = fn
+	cgMigrationsMu.Unlock()
+}
+
+// MigrateCg converts cg from schema version from to version to by chaining
+// registered migrations.
+func MigrateCg(cg []byte, from, to types.SchemaVersion) ([]byte, error) {
+	if from == to {
+		return cg, nil
+	}
+	cgMigrationsMu.RLock()
+	defer cgMigrationsMu.RUnlock()
+
+	// breadth first search for the shortest chain of migrations
+	prev := map[types.SchemaVersion]types.SchemaVersion{from: from}
+	queue := []types.SchemaVersion{from}
+	for len(queue) > 0 && prev[to] == "" {
+		v := queue[0]
+		queue = queue[1:]
+		for k := range cgMigrations {
+			if k.from == v && prev[k.to] == "" {
+				prev[k.to] = v
+				queue = append(queue, k.to)
+			}
+		}
+	}
+	if prev[to] == "" {
+		return nil, fmt.Errorf("no callgraph migration from schema %s to %s", from, to)
+	}
+	var chain []types.SchemaVersion
+	for v := to; v != from; v = prev[v] {
+		chain = append([]types.SchemaVersion{v}, chain...)
+	}
+	current := from
+	for _, next := range chain {
+		var err error
+		if cg, err = cgMigrations[cgMigrationKey{current, next}](cg); err != nil {
+			return nil, fmt.Errorf("migrating callgraph from schema %s to %s: %w", current, next, err)
+		}
+		current = next
+	}
+	return cg, nil
+}
+
+// WithCgSchemaVersion declares the schema version of the callgraphs passed
+// to UploadCg. The version is sent to the server with each upload.
+func WithCgSchemaVersion(v types.SchemaVersion) Option {
+	return func(c *HTTPClient) {
+		c.cgSchema = v
+	}
+}
+
+// NegotiateCgSchema picks the newest callgraph schema version accepted by
+// the server which is not newer than the one produced by the agent (see
+// WithCgSchemaVersion). UploadCg then migrates callgraphs to that version
+// before uploading them. It may be called again, eg after a server
+// upgrade, while uploads are in flight.
+func (c *HTTPClient) NegotiateCgSchema(ctx context.Context) (types.SchemaVersion, error) {
+	info, err := c.HealthzInfo(ctx)
+	if err != nil {
+		return "", err
+	}
+	produced := c.cgSchema
+	if produced == "" {
+		produced = types.CgSchemaLatest
+	}
+	supported := info.CgSchemaVersions()
+	// the server only accepts newer schemas unless one is found below,
+	// upgrade to the oldest of them
+	negotiated := supported[0]
+	for i := len(supported) - 1; i >= 0; i-- {
+		if supported[i].Compare(produced) <= 0 {
+			negotiated = supported[i]
+			break
+		}
+	}
+	c.initMu.Lock()
+	c.cgUploadSchema = negotiated
+	c.initMu.Unlock()
+	return negotiated, nil
+}
+
+// prepareCg migrates cg to the negotiated schema and returns it along with
+// the schema version to report to the server. Callgraphs of an undeclared
+// schema are assumed to be of the latest one, as in NegotiateCgSchema.
+func (c *HTTPClient) prepareCg(cg []byte) ([]byte, types.SchemaVersion, error) {
+	c.initMu.Lock()
+	upload := c.cgUploadSchema
+	c.initMu.Unlock()
+	if upload == "" {
+		return cg, c.cgSchema, nil
+	}
+	produced := c.cgSchema
+	if produced == "" {
+		produced = types.CgSchemaLatest
+	}
+	if produced == upload {
+		return cg, upload, nil
+	}
+	migrated, err := MigrateCg(cg, produced, upload)
+	if err != nil {
+		return nil, "", err
+	}
+	return migrated, upload, nil
+}
@@ -0,0 +1,94 @@
+package client
+
+import (
+	"errors"
+	"io"
+	"math/rand"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// errChaos is the transport error injected by WithChaos.
+var errChaos = errors.New("chaos: injected transport error")
+
+// WithChaos injects faults into every request for verifying agent behaviour
+// under TI flakiness in staging builds. Each request is delayed by up to
+// latency and fails with probability errRate, evenly split between transport
+// errors and 503 responses. It must never be used in production builds.
+func WithChaos(errRate float64, latency time.Duration) Option {
+	return func(c *HTTPClient) {
+		c.chaos = &chaosTransport{errRate: errRate, latency: latency}
+	}
+}
+
+// chaosTransport is an http.RoundTripper injecting faults.
+type chaosTransport struct {
+	next    http.RoundTripper
+	errRate float64
+	latency time.Duration
+}
+
+func (t *chaosTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if t.latency > 0 {
+		delay := time.Duration(rand.Int63n(int64(t.latency) + 1)) //nolint:gosec
+		select {
+		case <-time.After(delay):
+		case <-req.Context().Done():
+			return nil, req.Context().Err()
+		}
+	}
+	if rand.Float64() < t.errRate { //nolint:gosec
+		if req.Body != nil {
+			req.Body.Close()
+		}
+		if rand.Intn(2) == 0 { //nolint:gosec
+			return nil, errChaos
+		}
+		body := `{"message":"chaos: injected server error"}`
+		return &http.Response{
+			Status:        "503 Service Unavailable",
+			StatusCode:    http.StatusServiceUnavailable,
+			Proto:         "HTTP/1.1",
+			ProtoMajor:    1,
+			ProtoMinor:    1,
+			Header:        http.Header{"Content-Type": []string{"application/json"}},
+			Body:          io.NopCloser(strings.NewReader(body)),
+			ContentLength: int64(len(body)),
+			Request:       req,
+		}, nil
+	}
+	return t.next.RoundTrip(req)
+}
+
+// wrapTransport returns a copy of hc whose transport is decorated by wrap.
+func wrapTransport(hc *http.Client, wrap func(next http.RoundTripper) http.RoundTripper) *http.Client {
+	wrapped := *hc
+	next := hc.Transport
+	if next == nil {
+		next = http.DefaultTransport
+	}
+	wrapped.Transport = wrap(next)
+	return &wrapped
+}
+
+// baseTransport returns the transport wrapped by the throttling and chaos
+// transports, if any.
+func baseTransport(rt http.RoundTripper) http.RoundTripper {
+	for {
+		switch t := rt.(type) {
+		case *chaosTransport:
+			rt = t.next
+		case *throttleTransport:
+			rt = t.next
+		default:
+			return rt
+		}
+	}
+}
+
+// wrap decorates next with the chaos transport.
+func (t *chaosTransport) wrap(next http.RoundTripper) http.RoundTripper {
+	t.next = next
+	return t
+}
@@ -2,22 +2,15 @@ package client
 
 import (
 	"context"
-	"fmt"
+	"time"
 
 	"github.com/harness/ti-client/types"
 )
 
-// Error is a custom error struct
-type Error struct {
-	Code    int
-	Message string
-}
-
-func (e *Error) Error() string {
-	return fmt.Sprintf("%d: %s", e.Code, e.Message)
-}
-
-// Client defines a TI service client.
+// Client defines a TI service client. Capabilities added since are defined
+// by the optional interfaces below, which callers check for with a type
+// assertion, so that implementations of Client keep compiling as the
+// service grows. HTTPClient implements all of them.
 type Client interface {
 	// Write test cases to DB
 	Write(ctx context.Context, step, report string, tests []*types.TestCase) error
@@ -52,3 +45,148 @@ type Client interface {
 	// WriteSavings writes time savings for a step/feature to TI server
 	WriteSavings(ctx context.Context, stepID string, featureName types.SavingsFeature, featureState types.IntelligenceExecutionState, timeTakenMs int64, savingsRequest types.SavingsRequest) error
 }
+
+// ResultWriter is implemented by clients which can write results other than
+// test cases.
+type ResultWriter interface {
+	// WriteManualResults writes the results of manually executed tests to DB
+	WriteManualResults(ctx context.Context, step, report string, results []*types.ManualTestResult) error
+
+	// WriteBenchmarks writes the results of performance tests to DB
+	WriteBenchmarks(ctx context.Context, step, report string, results []*types.BenchmarkResult) error
+}
+
+// AsyncSelector is implemented by clients which can compute test selections
+// asynchronously, see WaitForSelection.
+type AsyncSelector interface {
+	// SubmitSelectTests submits a test selection to be computed asynchronously and returns a ticket to poll
+	SubmitSelectTests(ctx context.Context, stepID, source, target string, in *types.SelectTestsReq) (types.SelectionTicket, error)
+
+	// GetSelectionTicket returns the status of an asynchronous test selection
+	GetSelectionTicket(ctx context.Context, ticketID string) (types.SelectionTicket, error)
+}
+
+// TestAnalyzer is implemented by clients which can report on the tests and
+// their selection beyond SelectTests and Summary.
+type TestAnalyzer interface {
+	// GetSelectionAudit returns the inputs, rules applied and outputs of the test selection of a step
+	GetSelectionAudit(ctx context.Context, stepID string) (types.SelectionAudit, error)
+
+	// GetSuiteTimes returns the test timing data aggregated per suite or class
+	GetSuiteTimes(ctx context.Context, step string, in *types.SuiteTimesReq) (types.SuiteTimesResp, error)
+
+	// GetTestOwners returns the teams owning the given test classes/files
+	GetTestOwners(ctx context.Context, stepID string, in *types.GetTestOwnersReq) (types.GetTestOwnersResp, error)
+
+	// GetTestGaps returns the changed source files and methods which are not covered by any test
+	GetTestGaps(ctx context.Context, stepID string, in *types.TestGapsReq) (types.TestGapsResp, error)
+
+	// GetImpactedTests returns the tests impacted by changes to the given files without creating a selection record
+	GetImpactedTests(ctx context.Context, files []types.File) (types.ImpactedTestsResp, error)
+
+	// GetRequirementCoverage returns the executed tests linked to the given issues or requirements
+	GetRequirementCoverage(ctx context.Context, in *types.RequirementCoverageReq) (types.RequirementCoverageResp, error)
+
+	// GetAlwaysRunTests returns the tests configured to never be skipped for the repository
+	GetAlwaysRunTests(ctx context.Context) ([]types.RunnableTest, error)
+}
+
+// Reprocessor is implemented by clients which can reprocess uploaded
+// reports, see WaitForReprocess.
+type Reprocessor interface {
+	// ReprocessReport asks the server to re-ingest and re-summarize a report already uploaded for a step
+	ReprocessReport(ctx context.Context, stepID, report string) (types.ReprocessJob, error)
+
+	// GetReprocessJob returns the status of a report reprocessing job
+	GetReprocessJob(ctx context.Context, jobID string) (types.ReprocessJob, error)
+}
+
+// Muter is implemented by clients which can manage muting rules.
+type Muter interface {
+	// ListMutingRules returns the muting rules of the repository
+	ListMutingRules(ctx context.Context) ([]types.MutingRule, error)
+
+	// CreateMutingRule creates a muting rule for the repository
+	CreateMutingRule(ctx context.Context, rule types.MutingRule) (types.MutingRule, error)
+
+	// DeleteMutingRule deletes the muting rule with the given id
+	DeleteMutingRule(ctx context.Context, id string) error
+}
+
+// WebhookManager is implemented by clients which can manage webhooks.
+type WebhookManager interface {
+	// RegisterWebhook registers a webhook receiving TI events of the project
+	RegisterWebhook(ctx context.Context, hook types.Webhook) (types.Webhook, error)
+
+	// ListWebhooks returns the webhooks registered for the project
+	ListWebhooks(ctx context.Context) ([]types.Webhook, error)
+
+	// DeleteWebhook deletes the webhook with the given id
+	DeleteWebhook(ctx context.Context, id string) error
+}
+
+// EventSubscriber is implemented by clients which can stream TI events.
+type EventSubscriber interface {
+	// SubscribeEvents streams the events of the given types (all the types if none is given) to handler
+	SubscribeEvents(ctx context.Context, handler func(types.Event) error, eventTypes ...types.EventType) error
+}
+
+// SavingsReporter is implemented by clients which can report on savings.
+type SavingsReporter interface {
+	// GetSavingsRollup returns the savings aggregated per project or pipeline over a time window
+	GetSavingsRollup(ctx context.Context, scope types.SavingsScope, window time.Duration) (types.SavingsRollup, error)
+
+	// ForecastSavings returns the savings projected from enabling TI, build cache or DLC for the repository
+	ForecastSavings(ctx context.Context, in *types.SavingsForecastReq) (types.SavingsForecast, error)
+}
+
+// HealthReporter is implemented by clients which can report on the server
+// beyond Healthz.
+type HealthReporter interface {
+	// HealthzInfo pings the healthz endpoint and returns version information about the server
+	HealthzInfo(ctx context.Context) (types.ServerInfo, error)
+}
+
+// HealthWatcher is implemented by clients which can monitor the health of
+// the server.
+type HealthWatcher interface {
+	// WatchHealth pings the healthz endpoint every interval until ctx is done and calls cb whenever the state changes
+	WatchHealth(ctx context.Context, interval time.Duration, cb func(HealthEvent))
+}
+
+// AgentChecker is implemented by clients which can check the compatibility
+// of agents with the server.
+type AgentChecker interface {
+	// CheckAgentCompatibility reports whether an agent version is supported by the server, with deprecation warnings
+	CheckAgentCompatibility(ctx context.Context, in types.AgentCompatibilityReq) (types.AgentCompatibility, error)
+}
+
+// AgentDownloader is implemented by clients which can download and verify
+// agent artifacts.
+type AgentDownloader interface {
+	// DownloadAgent downloads the agent artifact at link to dst, verifying its digest and signature when configured
+	DownloadAgent(ctx context.Context, link types.DownloadLink, dst, sha256Hex string) error
+
+	// VerifyAgent checks the signature referenced by link against the artifact stored at path
+	VerifyAgent(ctx context.Context, link types.DownloadLink, path string) error
+}
+
+// CgSchemaNegotiator is implemented by clients which can negotiate the
+// callgraph schema version with the server.
+type CgSchemaNegotiator interface {
+	// NegotiateCgSchema picks the newest callgraph schema version accepted by the server and produced by the agent
+	NegotiateCgSchema(ctx context.Context) (types.SchemaVersion, error)
+}
+
+// Diagnoser is implemented by clients which can diagnose their connectivity
+// to the server.
+type Diagnoser interface {
+	// Diagnose checks the connectivity to the server and returns a report
+	Diagnose(ctx context.Context) *DiagnosticReport
+}
+
+// Closer is implemented by clients which must be closed on shutdown.
+type Closer interface {
+	// Close waits for the calls in flight, or for ctx to be done, and releases the resources of the client
+	Close(ctx context.Context) error
+}
@@ -0,0 +1,335 @@
+package clienttest
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math/rand"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/harness/ti-client/client"
+	"github.com/harness/ti-client/types"
+)
+
+// Config holds the canned behaviour of a Server.
+type Config struct {
+	// Token, if set, must be sent by clients in X-Harness-Token.
+	Token string
+	// Latency is added before every response.
+	Latency time.Duration
+	// ErrorRate is the fraction (0-1) of requests answered with a 503.
+	ErrorRate float64
+
+	SelectTests   types.SelectTestsResp
+	TestTimes     types.GetTestTimesResp
+	SuiteTimes    types.SuiteTimesResp
+	DownloadLinks []types.DownloadLink
+	AgentCompat   types.AgentCompatibility
+	CommitInfo    types.CommitInfoResp
+	Summary       types.SummaryResponse
+	TestCases     []types.TestCase
+	Info          types.ServerInfo
+	TestOwners    types.GetTestOwnersResp
+	TestGaps      types.TestGapsResp
+	ImpactedTests types.ImpactedTestsResp
+	AlwaysRun     []types.RunnableTest
+	Requirements  types.RequirementCoverageResp
+	SelectAudit   types.SelectionAudit
+
+	// MutingRules and Webhooks are the rules and webhooks initially
+	// registered; they are updated by the create and delete calls.
+	MutingRules []types.MutingRule
+	Webhooks    []types.Webhook
+	// Events are streamed to every subscriber, after which the stream ends.
+	Events []types.Event
+	// ReprocessJob and SelectionTicket are returned both when the job or
+	// the asynchronous selection is submitted and when it is polled.
+	ReprocessJob    types.ReprocessJob
+	SelectionTicket types.SelectionTicket
+}
+
+// Request is a request received by a Server.
+type Request struct {
+	Method string
+	Path   string
+	Query  map[string]string
+	Header http.Header
+	Body   []byte
+}
+
+// Server is an in-process stub of the TI service for end-to-end tests of
+// agents and of the client retry logic.
+type Server struct {
+	*httptest.Server
+
+	mu       sync.Mutex
+	config   Config
+	failNext []int
+	requests []Request
+	nextID   int
+}
+
+// NewServer starts a stub TI server with the given configuration. Callers
+// must Close it when done.
+func NewServer(config Config) *Server {
+	s := &Server{config: config}
+	s.Server = httptest.NewServer(http.HandlerFunc(s.handle))
+	return s
+}
+
+// Configure updates the server configuration.
+func (s *Server) Configure(fn func(*Config)) {
+	s.mu.Lock()
+	fn(&s.config)
+	s.mu.Unlock()
+}
+
+// FailNext makes the next n requests fail with the given status code.
+func (s *Server) FailNext(n, code int) {
+	s.mu.Lock()
+	for i := 0; i < n; i++ {
+		s.failNext = append(s.failNext, code)
+	}
+	s.mu.Unlock()
+}
+
+// Requests returns the requests received so far.
+func (s *Server) Requests() []Request {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]Request(nil), s.requests...)
+}
+
+// RequestsTo returns the requests received for the given path.
+func (s *Server) RequestsTo(path string) []Request {
+	var matching []Request
+	for _, r := range s.Requests() {
+		if r.Path == path {
+			matching = append(matching, r)
+		}
+	}
+	return matching
+}
+
+// NewClient returns a client configured to talk to the server.
+func (s *Server) NewClient(opts ...client.Option) *client.HTTPClient {
+	s.mu.Lock()
+	token := s.config.Token
+	s.mu.Unlock()
+	if token == "" {
+		token = "token"
+	}
+	return client.NewHTTPClient(s.URL, token, "account", "org", "project", "pipeline", "build", "stage", "repo", "sha", "", false, "", opts...)
+}
+
+func (s *Server) handle(w http.ResponseWriter, r *http.Request) {
+	body, _ := io.ReadAll(r.Body)
+	query := map[string]string{}
+	for k, v := range r.URL.Query() {
+		query[k] = v[0]
+	}
+
+	s.mu.Lock()
+	s.requests = append(s.requests, Request{Method: r.Method, Path: r.URL.Path, Query: query, Header: r.Header.Clone(), Body: body})
+	config := s.config
+	failCode := 0
+	if len(s.failNext) > 0 {
+		failCode, s.failNext = s.failNext[0], s.failNext[1:]
+	}
+	s.mu.Unlock()
+
+	if config.Latency > 0 {
+		select {
+		case <-time.After(config.Latency):
+		case <-r.Context().Done():
+			return
+		}
+	}
+	if failCode == 0 && config.ErrorRate > 0 && rand.Float64() < config.ErrorRate { //nolint:gosec
+		failCode = http.StatusServiceUnavailable
+	}
+	if failCode != 0 {
+		writeJSON(w, failCode, map[string]string{"message": "injected failure"})
+		return
+	}
+	if config.Token != "" && r.URL.Path != "/healthz" && r.Header.Get("X-Harness-Token") != config.Token {
+		writeJSON(w, http.StatusUnauthorized, map[string]string{"message": "invalid token"})
+		return
+	}
+
+	switch r.URL.Path {
+	case "/healthz":
+		w.WriteHeader(http.StatusOK)
+	case "/info":
+		writeJSON(w, http.StatusOK, config.Info)
+	case "/reports/write", "/reports/manual/write", "/reports/benchmarks/write", "/tests/uploadcg", "/savings":
+		if r.Header.Get("X-Harness-Content-SHA256") != "" {
+			sum := sha256.Sum256(body)
+			w.Header().Set("X-Harness-Received-SHA256", hex.EncodeToString(sum[:]))
+		}
+		w.WriteHeader(http.StatusNoContent)
+	case "/tests/select", "/ml/tests/select":
+		writeJSON(w, http.StatusOK, config.SelectTests)
+	case "/tests/select/audit":
+		writeJSON(w, http.StatusOK, config.SelectAudit)
+	case "/tests/timedata":
+		writeJSON(w, http.StatusOK, config.TestTimes)
+	case "/tests/timedata/suites":
+		writeJSON(w, http.StatusOK, config.SuiteTimes)
+	case "/agents/link":
+		writeJSON(w, http.StatusOK, config.DownloadLinks)
+	case "/agents/compatibility":
+		writeJSON(w, http.StatusOK, config.AgentCompat)
+	case "/vcs/commitinfo":
+		writeJSON(w, http.StatusOK, config.CommitInfo)
+	case "/reports/summary":
+		writeJSON(w, http.StatusOK, config.Summary)
+	case "/tests/owners":
+		writeJSON(w, http.StatusOK, config.TestOwners)
+	case "/tests/gaps":
+		writeJSON(w, http.StatusOK, config.TestGaps)
+	case "/tests/impacted":
+		writeJSON(w, http.StatusOK, config.ImpactedTests)
+	case "/tests/alwaysrun":
+		writeJSON(w, http.StatusOK, config.AlwaysRun)
+	case "/tests/requirements":
+		writeJSON(w, http.StatusOK, config.Requirements)
+	case "/reports/test_cases":
+		writeJSON(w, http.StatusOK, paginate(config.TestCases, query["pageIndex"], query["pageSize"]))
+	case "/tests/mutes":
+		s.handleMutingRules(w, r.Method, query["id"], body)
+	case "/webhooks":
+		s.handleWebhooks(w, r.Method, query["id"], body)
+	case "/events":
+		writeEvents(w, config.Events)
+	case "/reports/reprocess", "/reports/reprocess/status":
+		writeJSON(w, http.StatusOK, config.ReprocessJob)
+	case "/tests/select/async", "/tests/select/async/status":
+		writeJSON(w, http.StatusOK, config.SelectionTicket)
+	default:
+		writeJSON(w, http.StatusNotFound, map[string]string{"message": "unknown endpoint " + r.URL.Path})
+	}
+}
+
+// handleMutingRules lists, creates or deletes muting rules.
+func (s *Server) handleMutingRules(w http.ResponseWriter, method, id string, body []byte) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	switch method {
+	case http.MethodGet:
+		writeJSON(w, http.StatusOK, s.config.MutingRules)
+	case http.MethodPost:
+		var rule types.MutingRule
+		if err := json.Unmarshal(body, &rule); err != nil {
+			writeJSON(w, http.StatusBadRequest, map[string]string{"message": err.Error()})
+			return
+		}
+		s.nextID++
+		rule.ID = strconv.Itoa(s.nextID)
+		s.config.MutingRules = append(s.config.MutingRules, rule)
+		writeJSON(w, http.StatusOK, rule)
+	case http.MethodDelete:
+		for i, rule := range s.config.MutingRules {
+			if rule.ID == id {
+				s.config.MutingRules = append(s.config.MutingRules[:i:i], s.config.MutingRules[i+1:]...)
+				w.WriteHeader(http.StatusNoContent)
+				return
+			}
+		}
+		writeJSON(w, http.StatusNotFound, map[string]string{"message": "unknown muting rule " + id})
+	default:
+		writeJSON(w, http.StatusMethodNotAllowed, map[string]string{"message": "method not allowed"})
+	}
+}
+
+// handleWebhooks lists, registers or deletes webhooks.
+func (s *Server) handleWebhooks(w http.ResponseWriter, method, id string, body []byte) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	switch method {
+	case http.MethodGet:
+		writeJSON(w, http.StatusOK, s.config.Webhooks)
+	case http.MethodPost:
+		var hook types.Webhook
+		if err := json.Unmarshal(body, &hook); err != nil {
+			writeJSON(w, http.StatusBadRequest, map[string]string{"message": err.Error()})
+			return
+		}
+		s.nextID++
+		hook.ID = strconv.Itoa(s.nextID)
+		hook.Secret = "secret-" + hook.ID
+		listed := hook
+		listed.Secret = ""
+		s.config.Webhooks = append(s.config.Webhooks, listed)
+		writeJSON(w, http.StatusOK, hook)
+	case http.MethodDelete:
+		for i, hook := range s.config.Webhooks {
+			if hook.ID == id {
+				s.config.Webhooks = append(s.config.Webhooks[:i:i], s.config.Webhooks[i+1:]...)
+				w.WriteHeader(http.StatusNoContent)
+				return
+			}
+		}
+		writeJSON(w, http.StatusNotFound, map[string]string{"message": "unknown webhook " + id})
+	default:
+		writeJSON(w, http.StatusMethodNotAllowed, map[string]string{"message": "method not allowed"})
+	}
+}
+
+// writeEvents streams events as server-sent events.
+func writeEvents(w http.ResponseWriter, events []types.Event) {
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.WriteHeader(http.StatusOK)
+	for _, e := range events {
+		data, _ := json.Marshal(e)
+		fmt.Fprintf(w, "event: %s\nid: %s\ndata: %s\n\n", e.Type, e.ID, data)
+	}
+	if f, ok := w.(http.Flusher); ok {
+		f.Flush()
+	}
+}
+
+// paginate returns the requested page of tests.
+func paginate(tests []types.TestCase, pageIndex, pageSize string) types.TestCases {
+	index, _ := strconv.Atoi(pageIndex)
+	if index < 0 {
+		index = 0
+	}
+	size, err := strconv.Atoi(pageSize)
+	if err != nil || size <= 0 {
+		size = len(tests)
+		if size == 0 {
+			size = 1
+		}
+	}
+	pages := (len(tests) + size - 1) / size
+	start, end := index*size, (index+1)*size
+	if start > len(tests) {
+		start = len(tests)
+	}
+	if end > len(tests) {
+		end = len(tests)
+	}
+	page := tests[start:end]
+	return types.TestCases{
+		Metadata: types.ResponseMetadata{
+			TotalPages:    pages,
+			TotalItems:    len(tests),
+			PageItemCount: len(page),
+			PageSize:      size,
+		},
+		Tests: page,
+	}
+}
+
+func writeJSON(w http.ResponseWriter, code int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(code)
+	json.NewEncoder(w).Encode(v) //nolint:errcheck
+}
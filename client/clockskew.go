@@ -0,0 +1,77 @@
+package client
+
+import (
+	"net/http"
+	"sync"
+	"time"
+)
+
+// defaultMaxClockSkew is the skew from which a warning is logged unless
+// set with WithClockSkewThreshold.
+const defaultMaxClockSkew = time.Minute
+
+// WithClockSkewThreshold sets the difference between the local clock and
+// the Date header of TI responses from which a warning is logged. A
+// negative threshold disables the warning.
+func WithClockSkewThreshold(threshold time.Duration) Option {
+	return func(c *HTTPClient) {
+		c.skew.threshold = threshold
+	}
+}
+
+// WithClockSkewObserver registers a callback receiving the clock skew
+// measured on every TI response carrying a Date header, e.g. to export it
+// as a metric. A positive skew means the server clock is ahead.
+func WithClockSkewObserver(fn func(skew time.Duration)) Option {
+	return func(c *HTTPClient) {
+		c.skew.observer = fn
+	}
+}
+
+// ClockSkew returns the clock skew measured on the last TI response, and
+// false if none was measured yet.
+func (c *HTTPClient) ClockSkew() (time.Duration, bool) {
+	c.skew.mu.Lock()
+	defer c.skew.mu.Unlock()
+	return c.skew.last, c.skew.known
+}
+
+// clockSkew tracks the skew between the local and the server clocks. The
+// zero value uses the default threshold.
+type clockSkew struct {
+	threshold time.Duration
+	observer  func(skew time.Duration)
+
+	mu     sync.Mutex
+	last   time.Duration
+	known  bool
+	warned bool
+}
+
+// observeClockSkew measures the skew from the Date header of res and warns,
+// once per client, when it exceeds the threshold. Date has a resolution of
+// a second, smaller skews are not meaningful.
+func (c *HTTPClient) observeClockSkew(res *http.Response) {
+	date, err := http.ParseTime(res.Header.Get("Date"))
+	if err != nil {
+		return
+	}
+	skew := time.Until(date)
+	threshold := c.skew.threshold
+	if threshold == 0 {
+		threshold = defaultMaxClockSkew
+	}
+
+	c.skew.mu.Lock()
+	c.skew.last, c.skew.known = skew, true
+	warn := threshold > 0 && (skew > threshold || skew < -threshold) && !c.skew.warned
+	c.skew.warned = c.skew.warned || warn
+	c.skew.mu.Unlock()
+
+	if warn {
+		c.logger().Warnf("ti: local clock differs from the TI server by %s, this may break token validation and savings timings", skew.Round(time.Second))
+	}
+	if c.skew.observer != nil {
+		c.skew.observer(skew)
+	}
+}
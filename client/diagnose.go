@@ -0,0 +1,187 @@
+package client
+
+import (
+	"context"
+	"crypto/tls"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// DiagnosticCheck is the result of a single connectivity check.
+type DiagnosticCheck struct {
+	Name     string        `json:"name"`
+	OK       bool          `json:"ok"`
+	Detail   string        `json:"detail"`
+	Duration time.Duration `json:"duration"`
+}
+
+// DiagnosticReport is the result of Diagnose.
+type DiagnosticReport struct {
+	Endpoint  string            `json:"endpoint"`
+	Time      time.Time         `json:"time"`
+	ClockSkew time.Duration     `json:"clock_skew"`
+	Checks    []DiagnosticCheck `json:"checks"`
+}
+
+// OK reports whether all checks passed.
+func (r *DiagnosticReport) OK() bool {
+	for _, check := range r.Checks {
+		if !check.OK {
+			return false
+		}
+	}
+	return true
+}
+
+// String formats the report for pasting into support tickets.
+func (r *DiagnosticReport) String() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "TI diagnostics for %s at %s\n", r.Endpoint, r.Time.Format(time.RFC3339))
+	for _, check := range r.Checks {
+		status := "OK  "
+		if !check.OK {
+			status = "FAIL"
+		}
+		fmt.Fprintf(&b, "[%s] %-10s %-8s %s\n", status, check.Name, check.Duration.Round(time.Millisecond), check.Detail)
+	}
+	return b.String()
+}
+
+// Diagnose checks DNS resolution, TCP and TLS connectivity, proxy
+// configuration, clock skew, token validity and healthz against the
+// endpoint the calls made with ctx are routed to and returns a structured
+// report. Failing checks are recorded in the report rather than returned
+// as errors.
+func (c *HTTPClient) Diagnose(ctx context.Context) *DiagnosticReport {
+	endpoint := c.endpoint(ctx)
+	report := &DiagnosticReport{Endpoint: SanitizeURL(endpoint), Time: time.Now().UTC()}
+	check := func(name string, fn func() (string, error)) bool {
+		start := time.Now()
+		detail, err := fn()
+		result := DiagnosticCheck{Name: name, OK: err == nil, Detail: detail, Duration: time.Since(start)}
+		if err != nil {
+			result.Detail = err.Error()
+		}
+		report.Checks = append(report.Checks, result)
+		return err == nil
+	}
+
+	u, err := url.Parse(endpoint)
+	if err != nil || u.Host == "" {
+		check("endpoint", func() (string, error) {
+			return "", fmt.Errorf("invalid endpoint %q", endpoint)
+		})
+		return report
+	}
+	host, port := u.Hostname(), u.Port()
+	if port == "" {
+		port = "80"
+		if u.Scheme == "https" {
+			port = "443"
+		}
+	}
+
+	check("proxy", func() (string, error) {
+		proxy, err := http.ProxyFromEnvironment(&http.Request{URL: u})
+		if err != nil {
+			return "", err
+		}
+		if proxy == nil {
+			return "no proxy configured", nil
+		}
+		return "using proxy " + SanitizeURL(proxy.String()), nil
+	})
+	dnsOK := check("dns", func() (string, error) {
+		addrs, err := net.DefaultResolver.LookupHost(ctx, host)
+		if err != nil {
+			return "", err
+		}
+		return strings.Join(addrs, ", "), nil
+	})
+	if dnsOK {
+		tcpOK := check("tcp", func() (string, error) {
+			var d net.Dialer
+			conn, err := d.DialContext(ctx, "tcp", net.JoinHostPort(host, port))
+			if err != nil {
+				return "", err
+			}
+			defer conn.Close()
+			return "connected to " + conn.RemoteAddr().String(), nil
+		})
+		if tcpOK && u.Scheme == "https" {
+			check("tls", func() (string, error) {
+				config, err := c.tlsConfig()
+				if err != nil {
+					return "", err
+				}
+				config.ServerName = host
+				d := tls.Dialer{Config: config}
+				conn, err := d.DialContext(ctx, "tcp", net.JoinHostPort(host, port))
+				if err != nil {
+					return "", err
+				}
+				defer conn.Close()
+				state := conn.(*tls.Conn).ConnectionState()
+				return fmt.Sprintf("%s, server certificate %s", tls.VersionName(state.Version), state.PeerCertificates[0].Subject), nil
+			})
+		}
+	}
+
+	skewKnown := false
+	check("healthz", func() (string, error) {
+		res, err := c.do(ctx, c.url(ctx, healthzEndpoint), "GET", "", nil, nil) //nolint:bodyclose
+		if err != nil {
+			return "", err
+		}
+		if date, derr := http.ParseTime(res.Header.Get("Date")); derr == nil {
+			report.ClockSkew, skewKnown = time.Until(date), true
+		}
+		return res.Status, nil
+	})
+	check("clock", func() (string, error) {
+		if !skewKnown {
+			return "", fmt.Errorf("server did not return a Date header")
+		}
+		skew := report.ClockSkew
+		if skew < 0 {
+			skew = -skew
+		}
+		if skew > time.Minute {
+			return "", fmt.Errorf("local clock differs from server by %s", report.ClockSkew.Round(time.Second))
+		}
+		return fmt.Sprintf("skew %s", report.ClockSkew.Round(time.Millisecond)), nil
+	})
+	check("token", func() (string, error) {
+		if c.Token == "" {
+			return "", fmt.Errorf("ti token is not set")
+		}
+		res, err := c.do(ctx, c.url(ctx, infoEndpoint), "GET", "", nil, nil) //nolint:bodyclose
+		if IsAuthError(err) {
+			return "", fmt.Errorf("token rejected by server: %s", err)
+		}
+		if err != nil {
+			return "", fmt.Errorf("could not verify token: %s", err)
+		}
+		if res.StatusCode < 200 || res.StatusCode > 299 {
+			return "", fmt.Errorf("could not verify token: %s", res.Status)
+		}
+		return "token accepted", nil
+	})
+	return report
+}
+
+// tlsConfig returns a copy of the TLS configuration used by the client.
+func (c *HTTPClient) tlsConfig() (*tls.Config, error) {
+	hc, err := c.httpClient()
+	if err != nil {
+		return nil, err
+	}
+	if t, ok := baseTransport(hc.Transport).(*http.Transport); ok && t.TLSClientConfig != nil {
+		return t.TLSClientConfig.Clone(), nil
+	}
+	return &tls.Config{InsecureSkipVerify: c.SkipVerify}, nil //nolint:gosec
+}
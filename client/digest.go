@@ -0,0 +1,73 @@
+package client
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net/http"
+)
+
+const (
+	// bodyDigestHeader carries the hex encoded SHA-256 digest of the body
+	// of upload requests, so the server can detect truncated uploads.
+	bodyDigestHeader = "X-Harness-Content-SHA256"
+	// receivedDigestHeader carries the digest of the body as received by
+	// the server.
+	receivedDigestHeader = "X-Harness-Received-SHA256"
+)
+
+// ErrDigestMismatch is matched by errors.Is when the server acknowledged a
+// different body digest than the one sent.
+var ErrDigestMismatch = errors.New("request body digest mismatch")
+
+// WithBodyDigestVerification makes Write and UploadCg fail with
+// ErrDigestMismatch unless the server acknowledges the digest of the
+// uploaded body in its response.
+func WithBodyDigestVerification() Option {
+	return func(c *HTTPClient) {
+		c.verifyDigests = true
+	}
+}
+
+type bodyDigestKey struct{}
+
+// withBodyDigest marks the requests made with ctx as uploads whose body
+// digest is sent.
+func withBodyDigest(ctx context.Context) context.Context {
+	return context.WithValue(ctx, bodyDigestKey{}, true)
+}
+
+// isUpload reports whether ctx was marked by withBodyDigest.
+func isUpload(ctx context.Context) bool {
+	on, _ := ctx.Value(bodyDigestKey{}).(bool)
+	return on
+}
+
+// setBodyDigest sets the digest header of upload requests and returns the
+// digest, or "" if none was set.
+func setBodyDigest(ctx context.Context, req *http.Request, body []byte) string {
+	if !isUpload(ctx) || body == nil {
+		return ""
+	}
+	sum := sha256.Sum256(body)
+	digest := hex.EncodeToString(sum[:])
+	req.Header.Set(bodyDigestHeader, digest)
+	return digest
+}
+
+// checkBodyDigest verifies the digest acknowledged by the server, if
+// verification is enabled.
+func (c *HTTPClient) checkBodyDigest(res *http.Response, digest string) error {
+	if digest == "" || !c.verifyDigests || res.StatusCode >= http.StatusMultipleChoices {
+		return nil
+	}
+	if got := res.Header.Get(receivedDigestHeader); got != digest {
+		if got == "" {
+			got = "none"
+		}
+		return fmt.Errorf("%w: sent %s, server acknowledged %s", ErrDigestMismatch, digest, got)
+	}
+	return nil
+}
@@ -0,0 +1,185 @@
+package client
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strconv"
+	"sync"
+
+	"github.com/harness/ti-client/types"
+)
+
+// minPartSize is the smallest part a parallel download is split into.
+const minPartSize = 8 << 20
+
+// WithDownloadParts makes DownloadAgent fetch artifacts in n concurrent
+// ranged requests when the server supports them.
+func WithDownloadParts(n int) Option {
+	return func(c *HTTPClient) {
+		c.downloadParts = n
+	}
+}
+
+// DownloadAgent downloads the agent artifact at link to the file dst. When
+// sha256Hex is not empty the downloaded content is verified against it, and
+// when agent signing keys are configured its signature is checked, before
+// dst is written.
+func (c *HTTPClient) DownloadAgent(ctx context.Context, link types.DownloadLink, dst, sha256Hex string) error {
+	ctx, end, err := c.begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer end()
+	if len(c.agentKeyErrs) > 0 {
+		return fmt.Errorf("refusing to download %s, agent signature verification is misconfigured: %w", link.RelPath, errors.Join(c.agentKeyErrs...))
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(dst), filepath.Base(dst)+".*.part")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	size, ranged, err := c.probeDownload(ctx, link.URL)
+	if err != nil {
+		tmp.Close()
+		return err
+	}
+	parts := c.downloadParts
+	if max := int(size / minPartSize); parts > max {
+		parts = max
+	}
+	if ranged && parts > 1 {
+		err = c.downloadParallel(ctx, link.URL, tmp, size, parts)
+	} else {
+		err = c.downloadRange(ctx, link.URL, tmp, 0, -1)
+	}
+	if err != nil {
+		tmp.Close()
+		return err
+	}
+
+	if sha256Hex != "" {
+		if _, err := tmp.Seek(0, io.SeekStart); err != nil {
+			tmp.Close()
+			return err
+		}
+		h := sha256.New()
+		if _, err := io.Copy(h, tmp); err != nil {
+			tmp.Close()
+			return err
+		}
+		if got := hex.EncodeToString(h.Sum(nil)); got != sha256Hex {
+			tmp.Close()
+			return fmt.Errorf("checksum mismatch for %s: got sha256 %s, expected %s", link.RelPath, got, sha256Hex)
+		}
+	}
+	if len(c.agentKeys) > 0 {
+		if err := c.verifyAgent(ctx, link, tmp); err != nil {
+			tmp.Close()
+			return err
+		}
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), dst)
+}
+
+// probeDownload returns the size of the artifact at url and whether the
+// server accepts ranged requests for it.
+func (c *HTTPClient) probeDownload(ctx context.Context, url string) (int64, bool, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodHead, url, nil)
+	if err != nil {
+		return 0, false, err
+	}
+	hc, err := c.httpClient()
+	if err != nil {
+		return 0, false, err
+	}
+	res, err := hc.Do(req)
+	if err != nil {
+		return 0, false, sanitizeError(err)
+	}
+	res.Body.Close()
+	if res.StatusCode >= http.StatusMultipleChoices {
+		// some storage backends reject HEAD, fall back to a plain download
+		return 0, false, nil
+	}
+	return res.ContentLength, res.Header.Get("Accept-Ranges") == "bytes" && res.ContentLength > 0, nil
+}
+
+// downloadParallel downloads size bytes of url into f in parts concurrent
+// ranged requests.
+func (c *HTTPClient) downloadParallel(ctx context.Context, url string, f *os.File, size int64, parts int) error {
+	if err := f.Truncate(size); err != nil {
+		return err
+	}
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	var (
+		wg       sync.WaitGroup
+		once     sync.Once
+		firstErr error
+	)
+	partSize := (size + int64(parts) - 1) / int64(parts)
+	for start := int64(0); start < size; start += partSize {
+		start, end := start, start+partSize-1
+		if end >= size {
+			end = size - 1
+		}
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if err := c.downloadRange(ctx, url, f, start, end); err != nil {
+				once.Do(func() {
+					firstErr = err
+					cancel()
+				})
+			}
+		}()
+	}
+	wg.Wait()
+	return firstErr
+}
+
+// downloadRange writes the bytes start-end of url to f at offset start. An
+// end of -1 downloads the whole artifact.
+func (c *HTTPClient) downloadRange(ctx context.Context, url string, f *os.File, start, end int64) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return err
+	}
+	want := http.StatusOK
+	if end >= 0 {
+		req.Header.Set("Range", "bytes="+strconv.FormatInt(start, 10)+"-"+strconv.FormatInt(end, 10))
+		want = http.StatusPartialContent
+	}
+	hc, err := c.httpClient()
+	if err != nil {
+		return err
+	}
+	res, err := hc.Do(req)
+	if err != nil {
+		return sanitizeError(err)
+	}
+	defer res.Body.Close()
+	if res.StatusCode != want {
+		return fmt.Errorf("downloading %s: unexpected status %s", SanitizeURL(url), res.Status)
+	}
+	n, err := io.Copy(io.NewOffsetWriter(f, start), res.Body)
+	if err != nil {
+		return err
+	}
+	if end >= 0 && n != end-start+1 {
+		return fmt.Errorf("downloading %s: got %d bytes for range %d-%d", SanitizeURL(url), n, start, end)
+	}
+	return nil
+}
@@ -0,0 +1,83 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"path"
+	"strings"
+)
+
+type endpointKey struct{}
+
+// WithEndpoint returns a copy of ctx sending the calls made with it to
+// endpoint instead of the client endpoint, eg to route UploadCg to a
+// regional ingestion endpoint. The base path, token and TLS settings of the
+// client still apply.
+func WithEndpoint(ctx context.Context, endpoint string) context.Context {
+	return context.WithValue(ctx, endpointKey{}, strings.TrimSuffix(endpoint, "/"))
+}
+
+// endpointFrom returns the endpoint override carried by ctx, if any.
+func endpointFrom(ctx context.Context) string {
+	e, _ := ctx.Value(endpointKey{}).(string)
+	return e
+}
+
+// RoutingRule routes the calls for matching accounts and repositories to a
+// regional endpoint, eg to keep the test data of some repositories in an EU
+// hosted TI instance. Account and Repo are path.Match patterns, an empty
+// pattern matches anything.
+type RoutingRule struct {
+	Account  string
+	Repo     string
+	Endpoint string
+}
+
+// matches reports whether the rule applies to the account and repository.
+func (r RoutingRule) matches(account, repo string) bool {
+	return matchPattern(r.Account, account) && matchPattern(r.Repo, repo)
+}
+
+func matchPattern(pattern, s string) bool {
+	if pattern == "" {
+		return true
+	}
+	ok, _ := path.Match(pattern, s)
+	return ok
+}
+
+// WithRoutingRules routes the calls of the client to the endpoint of the
+// first matching rule, evaluated against the account of the client and the
+// repository of each call. Calls matching no rule go to the client
+// endpoint, and WithEndpoint takes precedence over the rules. An invalid
+// pattern fails every call of the client, even when not strict, as
+// silently sending data to the wrong endpoint is not an option.
+func WithRoutingRules(rules ...RoutingRule) Option {
+	return func(c *HTTPClient) {
+	next:
+		for _, r := range rules {
+			for _, pattern := range []string{r.Account, r.Repo} {
+				if _, err := path.Match(pattern, ""); err != nil {
+					c.routeErrs = append(c.routeErrs, fmt.Errorf("invalid routing pattern %q: %w", pattern, err))
+					continue next
+				}
+			}
+			r.Endpoint = strings.TrimSuffix(r.Endpoint, "/")
+			c.routes = append(c.routes, r)
+		}
+	}
+}
+
+// endpoint returns the endpoint the calls made with ctx are sent to.
+func (c *HTTPClient) endpoint(ctx context.Context) string {
+	if e := endpointFrom(ctx); e != "" {
+		return e
+	}
+	repo := c.repo(ctx)
+	for _, r := range c.routes {
+		if r.matches(c.AccountID, repo) {
+			return r.Endpoint
+		}
+	}
+	return c.Endpoint
+}
@@ -0,0 +1,127 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+)
+
+// Error is a custom error struct
+type Error struct {
+	Code    int
+	Message string
+
+	// Method and URL (sanitized) of the failed request.
+	Method string `json:"-"`
+	URL    string `json:"-"`
+	// RequestID is the request ID echoed by the server, or the one sent.
+	RequestID string `json:"-"`
+	// RetryAttempts is the number of attempts made before giving up.
+	RetryAttempts int `json:"-"`
+	// ServerCode is the application error code returned by the server, if any.
+	ServerCode string `json:"-"`
+
+	// ContentType is the content type of the error response.
+	ContentType string `json:"-"`
+	// Snippet holds the captured error response body, limited to the
+	// client's error body limit.
+	Snippet string `json:"-"`
+	// Truncated reports whether the response body exceeded the limit.
+	Truncated bool `json:"-"`
+
+	// CorrelationID identifies the logical operation (shared by all of
+	// its retries) that produced the error.
+	CorrelationID string `json:"-"`
+
+	// Err is the underlying transport error when no response was received.
+	Err error `json:"-"`
+}
+
+func (e *Error) Error() string {
+	if e.Code == 0 && e.Err != nil {
+		return e.Err.Error()
+	}
+	return fmt.Sprintf("%d: %s", e.Code, e.Message)
+}
+
+// Unwrap returns the underlying transport error, if any.
+func (e *Error) Unwrap() error {
+	return e.Err
+}
+
+// Temporary reports whether the request may succeed if retried: transport
+// errors, request timeouts, throttling and server errors are temporary.
+func (e *Error) Temporary() bool {
+	if e.Code == 0 {
+		return e.Err != nil && !errors.Is(e.Err, context.Canceled) && !errors.Is(e.Err, context.DeadlineExceeded)
+	}
+	return e.Code == http.StatusRequestTimeout ||
+		e.Code == http.StatusTooManyRequests ||
+		e.Code >= http.StatusInternalServerError
+}
+
+// serverError is the error payload returned by the TI service.
+type serverError struct {
+	Message   string      `json:"message"`
+	Code      interface{} `json:"code"`
+	ErrorCode string      `json:"error_code"`
+}
+
+// code returns the application error code of the payload as a string.
+func (s *serverError) code() string {
+	if s.ErrorCode != "" {
+		return s.ErrorCode
+	}
+	switch v := s.Code.(type) {
+	case string:
+		return v
+	case float64:
+		return fmt.Sprintf("%d", int64(v))
+	}
+	return ""
+}
+
+// withAttempts records the number of attempts made on err if it is an *Error.
+func withAttempts(err error, attempts int) error {
+	var e *Error
+	if errors.As(err, &e) {
+		e.RetryAttempts = attempts
+	}
+	return err
+}
+
+// IsRetryable reports whether err is a temporary failure that may succeed
+// if the call is retried.
+func IsRetryable(err error) bool {
+	var e *Error
+	if errors.As(err, &e) {
+		return e.Temporary()
+	}
+	return false
+}
+
+// IsAuthError reports whether err was caused by the server rejecting the
+// token or denying access.
+func IsAuthError(err error) bool {
+	return hasCode(err, http.StatusUnauthorized, http.StatusForbidden)
+}
+
+// IsNotFound reports whether err was caused by the requested resource not
+// existing on the server.
+func IsNotFound(err error) bool {
+	return hasCode(err, http.StatusNotFound)
+}
+
+func hasCode(err error, codes ...int) bool {
+	var e *Error
+	if !errors.As(err, &e) {
+		return false
+	}
+	for _, code := range codes {
+		if e.Code == code {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,104 @@
+package client
+
+import (
+	"bufio"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/harness/ti-client/types"
+)
+
+// WebhookSignatureHeader carries the signature of webhook deliveries.
+const WebhookSignatureHeader = "X-Harness-TI-Signature"
+
+// VerifyWebhookSignature reports whether signature, the value of the
+// WebhookSignatureHeader of a delivery, is the hex encoded HMAC-SHA256 of
+// body with the webhook secret.
+func VerifyWebhookSignature(secret string, body []byte, signature string) bool {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(body)
+	expected := mac.Sum(nil)
+	got, err := hex.DecodeString(strings.TrimPrefix(signature, "sha256="))
+	return err == nil && hmac.Equal(got, expected)
+}
+
+// SubscribeEvents streams the events of the given types (all the types if
+// none is given) from the server-sent events endpoint and calls handler for
+// each of them. It returns when ctx is done, the server closes the stream
+// or handler returns an error.
+func (c *HTTPClient) SubscribeEvents(ctx context.Context, handler func(types.Event) error, eventTypes ...types.EventType) error {
+	ctx, end, err := c.begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer end()
+	// the stream never drains by itself, end it if Close gives up waiting
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	go func() {
+		select {
+		case <-c.life.abortedc():
+			cancel()
+		case <-ctx.Done():
+		}
+	}()
+	if err := c.validateProjectArgs(); err != nil {
+		return err
+	}
+	names := make([]string, len(eventTypes))
+	for i, t := range eventTypes {
+		names[i] = string(t)
+	}
+	path := fmt.Sprintf(eventsEndpoint, c.AccountID, c.OrgID, c.ProjectID, url.QueryEscape(strings.Join(names, ",")))
+	u := c.url(ctx, path)
+	res, err := c.open(ctx, u, "GET", nil)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+	if res.StatusCode >= http.StatusMultipleChoices {
+		body, _ := io.ReadAll(io.LimitReader(res.Body, c.errorBodyLimit()))
+		return &Error{Code: res.StatusCode, Message: strings.TrimSpace(string(body)), Method: "GET", URL: SanitizeURL(u)}
+	}
+	return readEvents(res.Body, handler)
+}
+
+// readEvents decodes a text/event-stream of JSON encoded events.
+func readEvents(r io.Reader, handler func(types.Event) error) error {
+	s := bufio.NewScanner(r)
+	s.Buffer(nil, 4<<20)
+	var data strings.Builder
+	for s.Scan() {
+		line := s.Text()
+		switch {
+		case line == "":
+			if data.Len() == 0 {
+				continue
+			}
+			var e types.Event
+			if err := json.Unmarshal([]byte(data.String()), &e); err != nil {
+				return fmt.Errorf("could not decode event: %w", err)
+			}
+			data.Reset()
+			if err := handler(e); err != nil {
+				return err
+			}
+		case strings.HasPrefix(line, "data:"):
+			if data.Len() > 0 {
+				data.WriteByte('\n')
+			}
+			data.WriteString(strings.TrimPrefix(strings.TrimPrefix(line, "data:"), " "))
+		}
+		// comments (keep-alives), event and id fields are ignored, the
+		// event JSON carries its type and id
+	}
+	return s.Err()
+}
@@ -0,0 +1,140 @@
+package client
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+
+	"github.com/harness/ti-client/types"
+)
+
+// ExportFormat is the output format of ExportTestCases.
+type ExportFormat string
+
+// ExportColumn is a test case field included in an export.
+type ExportColumn string
+
+const (
+	ExportCSV    ExportFormat = "csv"
+	ExportNDJSON ExportFormat = "ndjson"
+
+	ColumnName       ExportColumn = "name"
+	ColumnClassName  ExportColumn = "class_name"
+	ColumnFileName   ExportColumn = "file_name"
+	ColumnSuiteName  ExportColumn = "suite_name"
+	ColumnStatus     ExportColumn = "status"
+	ColumnMessage    ExportColumn = "message"
+	ColumnType       ExportColumn = "type"
+	ColumnDurationMs ExportColumn = "duration_ms"
+	ColumnStdout     ExportColumn = "stdout"
+	ColumnStderr     ExportColumn = "stderr"
+
+	defaultExportPageSize = 500
+)
+
+// DefaultExportColumns are the columns exported when none are given.
+var DefaultExportColumns = []ExportColumn{ColumnName, ColumnClassName, ColumnSuiteName, ColumnStatus, ColumnDurationMs}
+
+// ExportTestCases streams every page of GetTestCases for req into w in the
+// given format, restricted to columns (DefaultExportColumns if empty). It
+// returns the number of test cases written.
+func ExportTestCases(ctx context.Context, c Client, req types.TestCasesRequest, format ExportFormat, w io.Writer, columns ...ExportColumn) (int, error) {
+	if len(columns) == 0 {
+		columns = DefaultExportColumns
+	}
+	for _, col := range columns {
+		if _, err := exportValue(&types.TestCase{}, col); err != nil {
+			return 0, err
+		}
+	}
+
+	var write func(tc *types.TestCase) error
+	var flush func() error
+	switch format {
+	case ExportCSV:
+		cw := csv.NewWriter(w)
+		header := make([]string, len(columns))
+		for i, col := range columns {
+			header[i] = string(col)
+		}
+		if err := cw.Write(header); err != nil {
+			return 0, err
+		}
+		write = func(tc *types.TestCase) error {
+			record := make([]string, len(columns))
+			for i, col := range columns {
+				v, _ := exportValue(tc, col)
+				record[i] = fmt.Sprint(v)
+			}
+			return cw.Write(record)
+		}
+		flush = func() error {
+			cw.Flush()
+			return cw.Error()
+		}
+	case ExportNDJSON:
+		enc := json.NewEncoder(w)
+		write = func(tc *types.TestCase) error {
+			record := make(map[string]interface{}, len(columns))
+			for _, col := range columns {
+				record[string(col)], _ = exportValue(tc, col)
+			}
+			return enc.Encode(record)
+		}
+		flush = func() error { return nil }
+	default:
+		return 0, fmt.Errorf("unknown export format %q", format)
+	}
+
+	if req.PageSize == "" {
+		req.PageSize = strconv.Itoa(defaultExportPageSize)
+	}
+	count := 0
+	for page := 0; ; page++ {
+		req.PageIndex = strconv.Itoa(page)
+		resp, err := c.GetTestCases(ctx, req)
+		if err != nil {
+			return count, err
+		}
+		for i := range resp.Tests {
+			if err := write(&resp.Tests[i]); err != nil {
+				return count, err
+			}
+			count++
+		}
+		if len(resp.Tests) == 0 || page+1 >= resp.Metadata.TotalPages {
+			break
+		}
+	}
+	return count, flush()
+}
+
+// exportValue returns the value of column col of tc.
+func exportValue(tc *types.TestCase, col ExportColumn) (interface{}, error) {
+	switch col {
+	case ColumnName:
+		return tc.Name, nil
+	case ColumnClassName:
+		return tc.ClassName, nil
+	case ColumnFileName:
+		return tc.FileName, nil
+	case ColumnSuiteName:
+		return tc.SuiteName, nil
+	case ColumnStatus:
+		return tc.Result.Status, nil
+	case ColumnMessage:
+		return tc.Result.Message, nil
+	case ColumnType:
+		return tc.Result.Type, nil
+	case ColumnDurationMs:
+		return tc.DurationMs, nil
+	case ColumnStdout:
+		return tc.SystemOut, nil
+	case ColumnStderr:
+		return tc.SystemErr, nil
+	}
+	return nil, fmt.Errorf("unknown export column %q", col)
+}
@@ -0,0 +1,89 @@
+package client
+
+import (
+	"context"
+	"time"
+
+	"github.com/harness/ti-client/types"
+)
+
+// FallbackReason describes why a FallbackSelector did not use the
+// preferred selection strategy.
+type FallbackReason string
+
+const (
+	// FallbackNone means ML based selection succeeded.
+	FallbackNone FallbackReason = ""
+	// FallbackMLSkipped means no ML request was provided.
+	FallbackMLSkipped FallbackReason = "ml_skipped"
+	// FallbackMLFailed means ML based selection failed and regular
+	// selection was used instead.
+	FallbackMLFailed FallbackReason = "ml_failed"
+	// FallbackRunAll means selection was unavailable and all tests
+	// should be run.
+	FallbackRunAll FallbackReason = "run_all"
+)
+
+// SelectionResult is the outcome of a FallbackSelector call.
+type SelectionResult struct {
+	Resp   types.SelectTestsResp
+	Reason FallbackReason
+	// Errors holds the errors of the strategies which were skipped, in
+	// the order they were attempted.
+	Errors []error
+}
+
+// FallbackSelector selects tests using ML based selection, falling back to
+// regular selection and finally to running all tests, so that a pipeline
+// never fails just because test selection was unavailable.
+type FallbackSelector struct {
+	Client Client
+	// MLTimeout bounds the ML selection call. Zero means no extra timeout.
+	MLTimeout time.Duration
+	// Timeout bounds the regular selection call. Zero means no extra timeout.
+	Timeout time.Duration
+}
+
+// Select returns the selected tests. mlReq may be nil to skip ML selection.
+func (s *FallbackSelector) Select(ctx context.Context, stepID, mlKey, source, target string, mlReq *types.MLSelectTestsRequest, req *types.SelectTestsReq) SelectionResult {
+	var result SelectionResult
+	result.Reason = FallbackMLSkipped
+	if mlReq != nil {
+		resp, err := s.mlSelect(ctx, stepID, mlKey, source, target, mlReq)
+		if err == nil {
+			result.Resp = resp
+			result.Reason = FallbackNone
+			return result
+		}
+		result.Errors = append(result.Errors, err)
+		result.Reason = FallbackMLFailed
+	}
+
+	resp, err := s.selectTests(ctx, stepID, source, target, req)
+	if err == nil {
+		result.Resp = resp
+		return result
+	}
+	result.Errors = append(result.Errors, err)
+	result.Resp = types.SelectTestsResp{SelectAll: true}
+	result.Reason = FallbackRunAll
+	return result
+}
+
+func (s *FallbackSelector) mlSelect(ctx context.Context, stepID, mlKey, source, target string, in *types.MLSelectTestsRequest) (types.SelectTestsResp, error) {
+	if s.MLTimeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, s.MLTimeout)
+		defer cancel()
+	}
+	return s.Client.MLSelectTests(ctx, stepID, mlKey, source, target, in)
+}
+
+func (s *FallbackSelector) selectTests(ctx context.Context, stepID, source, target string, in *types.SelectTestsReq) (types.SelectTestsResp, error) {
+	if s.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, s.Timeout)
+		defer cancel()
+	}
+	return s.Client.SelectTests(ctx, stepID, source, target, in)
+}
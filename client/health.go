@@ -0,0 +1,79 @@
+package client
+
+import (
+	"context"
+	"time"
+)
+
+// HealthState is the state of the TI service as observed by WatchHealth.
+type HealthState string
+
+const (
+	HealthUnknown   HealthState = ""
+	HealthHealthy   HealthState = "healthy"
+	HealthSlow      HealthState = "slow"
+	HealthUnhealthy HealthState = "unhealthy"
+)
+
+// HealthEvent is passed to the WatchHealth callback on state transitions.
+type HealthEvent struct {
+	State    HealthState
+	Previous HealthState
+	Latency  time.Duration
+	Err      error
+	Time     time.Time
+}
+
+// WithHealthLatencyThreshold sets the healthz latency above which
+// WatchHealth reports the service as slow rather than healthy.
+func WithHealthLatencyThreshold(d time.Duration) Option {
+	return func(c *HTTPClient) {
+		c.healthLatency = d
+	}
+}
+
+// WatchHealth pings the healthz endpoint every interval and calls cb
+// whenever the observed state changes, starting with the first check. It
+// blocks until ctx is done. interval defaults to 30 seconds.
+func (c *HTTPClient) WatchHealth(ctx context.Context, interval time.Duration, cb func(HealthEvent)) {
+	if interval <= 0 {
+		interval = 30 * time.Second
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	state := HealthUnknown
+	for {
+		start := time.Now()
+		checkCtx, cancel := context.WithTimeout(ctx, interval)
+		err := c.Healthz(checkCtx)
+		cancel()
+		if ctx.Err() != nil {
+			return
+		}
+		event := HealthEvent{
+			Previous: state,
+			Latency:  time.Since(start),
+			Err:      err,
+			Time:     start,
+		}
+		switch {
+		case err != nil:
+			event.State = HealthUnhealthy
+		case c.healthLatency > 0 && event.Latency > c.healthLatency:
+			event.State = HealthSlow
+		default:
+			event.State = HealthHealthy
+		}
+		if event.State != state {
+			state = event.State
+			cb(event)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
@@ -8,36 +8,80 @@ package client
 import (
 	"bytes"
 	"context"
+	"crypto"
+	"crypto/sha256"
 	"crypto/tls"
 	"crypto/x509"
+	"encoding/base64"
 	"encoding/json"
 	"errors"
 	"fmt"
 	"io"
 	"net/http"
+	"net/url"
 	"os"
-	"path/filepath"
 	"strconv"
 	"strings"
+	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/cenkalti/backoff"
 	"github.com/harness/ti-client/types"
 )
 
-var _ Client = (*HTTPClient)(nil)
+var (
+	_ Client             = (*HTTPClient)(nil)
+	_ ResultWriter       = (*HTTPClient)(nil)
+	_ AsyncSelector      = (*HTTPClient)(nil)
+	_ TestAnalyzer       = (*HTTPClient)(nil)
+	_ Reprocessor        = (*HTTPClient)(nil)
+	_ Muter              = (*HTTPClient)(nil)
+	_ WebhookManager     = (*HTTPClient)(nil)
+	_ EventSubscriber    = (*HTTPClient)(nil)
+	_ SavingsReporter    = (*HTTPClient)(nil)
+	_ HealthReporter     = (*HTTPClient)(nil)
+	_ HealthWatcher      = (*HTTPClient)(nil)
+	_ AgentChecker       = (*HTTPClient)(nil)
+	_ AgentDownloader    = (*HTTPClient)(nil)
+	_ CgSchemaNegotiator = (*HTTPClient)(nil)
+	_ Diagnoser          = (*HTTPClient)(nil)
+	_ Closer             = (*HTTPClient)(nil)
+)
 
 const (
 	dbEndpoint            = "/reports/write?accountId=%s&orgId=%s&projectId=%s&pipelineId=%s&buildId=%s&stageId=%s&stepId=%s&report=%s&repo=%s&sha=%s&commitLink=%s"
+	manualWriteEndpoint   = "/reports/manual/write?accountId=%s&orgId=%s&projectId=%s&pipelineId=%s&buildId=%s&stageId=%s&stepId=%s&report=%s&repo=%s&sha=%s&commitLink=%s"
+	benchmarksEndpoint    = "/reports/benchmarks/write?accountId=%s&orgId=%s&projectId=%s&pipelineId=%s&buildId=%s&stageId=%s&stepId=%s&report=%s&repo=%s&sha=%s&commitLink=%s"
+	selectAuditEndpoint   = "/tests/select/audit?accountId=%s&orgId=%s&projectId=%s&pipelineId=%s&buildId=%s&stageId=%s&stepId=%s"
 	testEndpoint          = "/tests/select?accountId=%s&orgId=%s&projectId=%s&pipelineId=%s&buildId=%s&stageId=%s&stepId=%s&repo=%s&sha=%s&source=%s&target=%s"
+	asyncSelectEndpoint   = "/tests/select/async?accountId=%s&orgId=%s&projectId=%s&pipelineId=%s&buildId=%s&stageId=%s&stepId=%s&repo=%s&sha=%s&source=%s&target=%s"
+	selectTicketEndpoint  = "/tests/select/async/status?accountId=%s&id=%s"
 	cgEndpoint            = "/tests/uploadcg?accountId=%s&orgId=%s&projectId=%s&pipelineId=%s&buildId=%s&stageId=%s&stepId=%s&repo=%s&sha=%s&source=%s&target=%s&timeMs=%d"
 	getTestsTimesEndpoint = "/tests/timedata?accountId=%s&orgId=%s&projectId=%s&pipelineId=%s&buildId=%s&stageId=%s&stepId=%s"
+	suiteTimesEndpoint    = "/tests/timedata/suites?accountId=%s&orgId=%s&projectId=%s&pipelineId=%s&buildId=%s&stageId=%s&stepId=%s"
 	agentEndpoint         = "/agents/link?accountId=%s&language=%s&os=%s&arch=%s&framework=%s&version=%s&buildenv=%s"
+	agentCompatEndpoint   = "/agents/compatibility?accountId=%s"
 	commitInfoEndpoint    = "/vcs/commitinfo?accountId=%s&orgId=%s&projectId=%s&pipelineId=%s&buildId=%s&stageId=%s&stepId=%s&repo=%s&branch=%s"
 	mlSelectTestsEndpoint = "/ml/tests/select?accountId=%s&orgId=%s&projectId=%s&pipelineId=%s&buildId=%s&stageId=%s&stepId=%s&repo=%s&sha=%s&source=%s&target=%s&mlKey=%s&commitLink=%s"
 	summaryEndpoint       = "/reports/summary?accountId=%s&orgId=%s&projectId=%s&pipelineId=%s&buildId=%s&stageId=%s&stepId=%s&report=%s"
 	testCasesEndpoint     = "/reports/test_cases?accountId=%s&orgId=%s&projectId=%s&pipelineId=%s&buildId=%s&stageId=%s&stepId=%s&report=%s&testCaseSearchTerm=%s&sort=%s&order=%s&pageIndex=%s&pageSize=%s&suite_name=%s"
+	testOwnersEndpoint    = "/tests/owners?accountId=%s&orgId=%s&projectId=%s&pipelineId=%s&buildId=%s&stageId=%s&stepId=%s&repo=%s&sha=%s"
+	mutingRulesEndpoint   = "/tests/mutes?accountId=%s&orgId=%s&projectId=%s&repo=%s"
+	mutingRuleEndpoint    = "/tests/mutes?accountId=%s&orgId=%s&projectId=%s&repo=%s&id=%s"
+	testGapsEndpoint      = "/tests/gaps?accountId=%s&orgId=%s&projectId=%s&pipelineId=%s&buildId=%s&stageId=%s&stepId=%s&repo=%s&sha=%s"
+	impactedTestsEndpoint = "/tests/impacted?accountId=%s&orgId=%s&projectId=%s&repo=%s&sha=%s"
+	requirementsEndpoint  = "/tests/requirements?accountId=%s&orgId=%s&projectId=%s&repo=%s"
+	alwaysRunEndpoint     = "/tests/alwaysrun?accountId=%s&orgId=%s&projectId=%s&repo=%s"
+	webhooksEndpoint      = "/webhooks?accountId=%s&orgId=%s&projectId=%s"
+	webhookEndpoint       = "/webhooks?accountId=%s&orgId=%s&projectId=%s&id=%s"
+	eventsEndpoint        = "/events?accountId=%s&orgId=%s&projectId=%s&types=%s"
+	reprocessEndpoint     = "/reports/reprocess?accountId=%s&orgId=%s&projectId=%s&pipelineId=%s&buildId=%s&stageId=%s&stepId=%s&report=%s"
+	reprocessJobEndpoint  = "/reports/reprocess/status?accountId=%s&id=%s"
+	forecastEndpoint      = "/savings/forecast?accountId=%s&orgId=%s&projectId=%s&repo=%s"
+	rollupEndpoint        = "/savings/rollup?accountId=%s&orgId=%s&projectId=%s&groupBy=%s&windowMs=%d"
 	healthzEndpoint       = "/healthz"
+	infoEndpoint          = "/info"
 	// savings
 	savingsEndpoint = "/savings?accountId=%s&orgId=%s&projectId=%s&pipelineId=%s&buildId=%s&stageId=%s&stepId=%s&repo=%s&featureName=%s&featureState=%s&timeMs=%s"
 )
@@ -50,7 +94,7 @@ var defaultClient = &http.Client{
 }
 
 // NewHTTPClient returns a new HTTPClient with optional mTLS and custom root certificates.
-func NewHTTPClient(endpoint, token, accountID, orgID, projectID, pipelineID, buildID, stageID, repo, sha, commitLink string, skipverify bool, additionalCertsDir string) *HTTPClient {
+func NewHTTPClient(endpoint, token, accountID, orgID, projectID, pipelineID, buildID, stageID, repo, sha, commitLink string, skipverify bool, additionalCertsDir string, opts ...Option) *HTTPClient {
 	endpoint = strings.TrimSuffix(endpoint, "/")
 	client := &HTTPClient{
 		Endpoint:   endpoint,
@@ -65,77 +109,121 @@ func NewHTTPClient(endpoint, token, accountID, orgID, projectID, pipelineID, bui
 		Sha:        sha,
 		CommitLink: commitLink,
 		SkipVerify: skipverify,
+		certsDir:   additionalCertsDir,
 	}
-
-	// Load mTLS certificates if available
-	mtlsEnabled, mtlsCerts := loadMTLSCerts("/etc/mtls/client.crt", "/etc/mtls/client.key")
-
-	// Load custom root CAs if additional certificates directory is provided
-	rootCAs := loadRootCAs(additionalCertsDir)
-
-	// Only create HTTP client if needed (mTLS, additional certs, or skipverify)
-	if skipverify || rootCAs != nil || mtlsEnabled {
-		client.Client = clientWithTLSConfig(skipverify, rootCAs, mtlsEnabled, mtlsCerts)
+	for _, opt := range opts {
+		opt(client)
 	}
-
 	return client
 }
 
-// loadMTLSCerts loads mTLS certificates if they exist
-func loadMTLSCerts(certFile, keyFile string) (bool, tls.Certificate) {
-	if fileExists(certFile) && fileExists(keyFile) {
-		mtlsCerts, err := tls.LoadX509KeyPair(certFile, keyFile)
-		if err != nil {
-			fmt.Printf("failed to load mTLS cert/key pair, error: %s\n", err)
-			return false, tls.Certificate{}
-		}
-		return true, mtlsCerts
+// NewHTTPClientStrict returns a new HTTPClient like NewHTTPClient, but loads
+// the configured security material right away and returns an error if any
+// of it fails to load: the mTLS certificate pair, the additional root CAs
+// (including files which are not valid certificates) or the certificates
+// and keys passed through options.
+func NewHTTPClientStrict(endpoint, token, accountID, orgID, projectID, pipelineID, buildID, stageID, repo, sha, commitLink string, skipverify bool, additionalCertsDir string, opts ...Option) (*HTTPClient, error) {
+	client := NewHTTPClient(endpoint, token, accountID, orgID, projectID, pipelineID, buildID, stageID, repo, sha, commitLink, skipverify, additionalCertsDir, opts...)
+	client.strict = true
+	if _, err := client.httpClient(); err != nil {
+		return nil, err
 	}
-	return false, tls.Certificate{}
+	return client, nil
 }
 
-// loadRootCAs loads custom root CAs from the provided directory
-func loadRootCAs(additionalCertsDir string) *x509.CertPool {
-	if additionalCertsDir == "" {
-		return nil
-	}
-
-	rootCAs, _ := x509.SystemCertPool()
-	if rootCAs == nil {
-		rootCAs = x509.NewCertPool()
-	}
+const (
+	mtlsCertFile = "/etc/mtls/client.crt"
+	mtlsKeyFile  = "/etc/mtls/client.key"
+)
 
-	fmt.Printf("additional certs dir to allow: %s\n", additionalCertsDir)
+// httpClient returns the http.Client used for requests. The TLS material
+// is loaded on first use, so that constructing a client does no disk IO;
+// loading errors are returned from every request.
+func (c *HTTPClient) httpClient() (*http.Client, error) {
+	c.initMu.Lock()
+	defer c.initMu.Unlock()
+	if !c.initDone {
+		c.initErr = c.initTransport()
+		c.initDone = true
+	}
+	if c.initErr != nil {
+		return nil, c.initErr
+	}
+	return c.clientOrDefault(), nil
+}
 
-	files, err := os.ReadDir(additionalCertsDir)
-	if err != nil {
-		fmt.Printf("could not read directory %s, error: %s\n", additionalCertsDir, err)
-		return rootCAs
+// clientOrDefault returns c.Client, or the default client if it is not set.
+func (c *HTTPClient) clientOrDefault() *http.Client {
+	if c.Client == nil {
+		return defaultClient
 	}
+	return c.Client
+}
 
-	// Go through all certs in this directory and add them to the global certs
-	for _, f := range files {
-		path := filepath.Join(additionalCertsDir, f.Name())
-		fmt.Printf("trying to add certs at: %s to root certs\n", path)
-		// Create TLS config using cert PEM
-		rootPem, err := os.ReadFile(path)
+// initTransport builds the http.Client from the configured mTLS client
+// certificate and root CAs, unless one was set explicitly. c.initMu must
+// be held.
+func (c *HTTPClient) initTransport() error {
+	if len(c.routeErrs) > 0 {
+		return errors.Join(c.routeErrs...)
+	}
+	if len(c.optErrs) > 0 {
+		if c.strict {
+			return errors.Join(c.optErrs...)
+		}
+		for _, err := range c.optErrs {
+			c.logger().Warnf("%s", err)
+		}
+	}
+	if c.Client == nil {
+		// prefer a certificate provided through options (e.g. backed by
+		// a keystore signer) over the one mounted in /etc/mtls
+		cert := c.clientCert
+		if cert == nil {
+			var err error
+			if cert, err = loadMTLSCerts(mtlsCertFile, mtlsKeyFile); err != nil {
+				if c.strict {
+					return err
+				}
+				c.logger().Warnf("%s", err)
+			}
+		}
+		rootCAs, err := loadRootCAs(c.certsDir, c.strict, c.logger())
 		if err != nil {
-			fmt.Printf("could not read certificate file (%s), error: %s\n", path, err.Error())
-			continue
+			return err
+		}
+		// Only create HTTP client if needed (mTLS, additional certs, or skipverify)
+		if c.SkipVerify || rootCAs != nil || cert != nil {
+			c.Client = clientWithTLSConfig(c.SkipVerify, rootCAs, cert)
+			c.ownTransport, _ = c.Client.Transport.(*http.Transport)
 		}
-		// Append certs to the global certs
-		ok := rootCAs.AppendCertsFromPEM(rootPem)
-		if !ok {
-			fmt.Printf("error adding cert (%s) to pool, please check format of the certs provided.\n", path)
-			continue
+		if rootCAs != nil && !c.SkipVerify && c.rootCAReload > 0 {
+			c.reloadRootCAs(c.Client, rootCAs)
 		}
-		fmt.Printf("successfully added cert at: %s to root certs\n", path)
 	}
-	return rootCAs
+	if c.throttle != nil {
+		c.Client = wrapTransport(c.clientOrDefault(), c.throttle.wrap)
+	}
+	if c.chaos != nil {
+		c.Client = wrapTransport(c.clientOrDefault(), c.chaos.wrap)
+	}
+	return nil
+}
+
+// loadMTLSCerts loads the mTLS certificate pair if it exists
+func loadMTLSCerts(certFile, keyFile string) (*tls.Certificate, error) {
+	if !fileExists(certFile) || !fileExists(keyFile) {
+		return nil, nil
+	}
+	cert, err := tls.LoadX509KeyPair(certFile, keyFile)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load mTLS cert/key pair: %w", err)
+	}
+	return &cert, nil
 }
 
 // clientWithTLSConfig creates an HTTP client with the provided TLS settings
-func clientWithTLSConfig(skipverify bool, rootCAs *x509.CertPool, mtlsEnabled bool, cert tls.Certificate) *http.Client {
+func clientWithTLSConfig(skipverify bool, rootCAs *x509.CertPool, cert *tls.Certificate) *http.Client {
 	config := &tls.Config{
 		InsecureSkipVerify: skipverify,
 	}
@@ -143,9 +231,8 @@ func clientWithTLSConfig(skipverify bool, rootCAs *x509.CertPool, mtlsEnabled bo
 	if !skipverify && rootCAs != nil {
 		config.RootCAs = rootCAs
 	}
-	if mtlsEnabled {
-		fmt.Println("setting mTLS Client Certs in TI Service Client")
-		config.Certificates = []tls.Certificate{cert}
+	if cert != nil {
+		config.Certificates = []tls.Certificate{*cert}
 	}
 	return &http.Client{
 		CheckRedirect: func(*http.Request, []*http.Request) error {
@@ -178,16 +265,133 @@ type HTTPClient struct {
 	Sha        string
 	CommitLink string
 	SkipVerify bool
+
+	// ParentPipelineID and ParentBuildID identify the parent pipeline
+	// execution of a child pipeline, see WithParentPipeline.
+	ParentPipelineID string
+	ParentBuildID    string
+
+	clientCert *tls.Certificate
+	certsDir   string
+	initMu     sync.Mutex
+	initDone   bool
+	initErr    error
+	strict     bool
+	optErrs    []error // errors of options loading security material
+	userAgent  string
+	log        Logger
+	debug      bool
+	traceHook  func(ctx context.Context, t ConnTimings)
+	auditLog   *auditLog
+
+	maxErrorBody int64
+	retryBudget  *retryBudget
+	shouldRetry  RetryFunc
+	selectCache  *selectionCache
+	linkCache    *linkCache
+	ownTransport *http.Transport // created by initTransport, closed by Close
+
+	healthLatency time.Duration
+	chaos         *chaosTransport
+	basePath      string
+	apiVersion    string
+
+	maxWriteSize    int64
+	maxUploadCgSize int64
+	splitWrites     bool
+
+	cgSchema       types.SchemaVersion
+	cgUploadSchema types.SchemaVersion
+
+	mlSelectTimeout time.Duration
+	mlSelectBudget  time.Duration
+	retryObserver   func(ctx context.Context, stats RetryStats)
+	downloadParts   int
+	agentKeys       map[string]crypto.PublicKey
+	agentKeyErrs    []error
+	rootCAReload    time.Duration
+	minConfidence   float64
+	pr              *types.PRMetadata
+	verifyDigests   bool
+	throttle        *throttleTransport
+	staging         *uploadStaging
+	uploads         uploadGuard
+	failDupUploads  bool
+	skew            clockSkew
+	life            lifecycle
+	readOnly        bool
+	routes          []RoutingRule
+	routeErrs       []error
+	adapters        map[adapterKey]ResponseAdapter
+	schemaVersion   atomic.Value
+	paramMode       ParameterizedMode
+	outputPolicy    OutputPolicy
+	outputBudget    int64
+	sampleRate      float64
 }
 
 // Write writes test results to the TI server
 func (c *HTTPClient) Write(ctx context.Context, stepID, report string, tests []*types.TestCase) error {
+	if err := c.checkWritable("write"); err != nil {
+		return err
+	}
+	if err := c.validateWriteArgs(stepID, report); err != nil {
+		return err
+	}
+	// suite-level failures aren't counted as failures of tests
+	types.MarkSuiteFailures(tests)
+	tests = c.captureOutput(c.sampleResults(c.shapeParameterized(tests)))
+	if err := checkPayloadSize("write", &tests, c.maxWriteSize, "split the report into smaller batches or reduce captured stdout/stderr"); err != nil {
+		if c.splitWrites && errors.Is(err, ErrPayloadTooLarge) {
+			return c.writeSplit(ctx, stepID, report, tests)
+		}
+		return err
+	}
+	return c.write(ctx, stepID, report, tests)
+}
+
+// write sends a single batch of test results to the TI server
+func (c *HTTPClient) write(ctx context.Context, stepID, report string, tests []*types.TestCase) error {
+	ctx, _ = ensureCorrelationID(ctx)
+	ctx = withBodyDigest(ctx)
+	path := fmt.Sprintf(dbEndpoint, c.AccountID, c.OrgID, c.ProjectID, c.PipelineID, c.BuildID, c.StageID, stepID, report, c.repo(ctx), c.sha(ctx), c.CommitLink)
+	backoff := createBackoff(10 * 60 * time.Second)
+	_, err := c.retry(ctx, c.url(ctx, path), "POST", c.sha(ctx), &tests, nil, false, false, backoff) //nolint:bodyclose
+	c.audit(ctx, "write", path, stepID, tests, err)
+	return err
+}
+
+// WriteManualResults writes the results of manually executed tests to the TI server
+func (c *HTTPClient) WriteManualResults(ctx context.Context, stepID, report string, results []*types.ManualTestResult) error {
+	if err := c.checkWritable("write_manual"); err != nil {
+		return err
+	}
+	if err := c.validateWriteArgs(stepID, report); err != nil {
+		return err
+	}
+	ctx, _ = ensureCorrelationID(ctx)
+	ctx = withBodyDigest(ctx)
+	path := fmt.Sprintf(manualWriteEndpoint, c.AccountID, c.OrgID, c.ProjectID, c.PipelineID, c.BuildID, c.StageID, stepID, report, c.repo(ctx), c.sha(ctx), c.CommitLink)
+	backoff := createBackoff(10 * 60 * time.Second)
+	_, err := c.retry(ctx, c.url(ctx, path), "POST", c.sha(ctx), &results, nil, false, false, backoff) //nolint:bodyclose
+	c.audit(ctx, "write_manual", path, stepID, results, err)
+	return err
+}
+
+// WriteBenchmarks writes the results of performance tests to the TI server
+func (c *HTTPClient) WriteBenchmarks(ctx context.Context, stepID, report string, results []*types.BenchmarkResult) error {
+	if err := c.checkWritable("write_benchmarks"); err != nil {
+		return err
+	}
 	if err := c.validateWriteArgs(stepID, report); err != nil {
 		return err
 	}
-	path := fmt.Sprintf(dbEndpoint, c.AccountID, c.OrgID, c.ProjectID, c.PipelineID, c.BuildID, c.StageID, stepID, report, c.Repo, c.Sha, c.CommitLink)
+	ctx, _ = ensureCorrelationID(ctx)
+	ctx = withBodyDigest(ctx)
+	path := fmt.Sprintf(benchmarksEndpoint, c.AccountID, c.OrgID, c.ProjectID, c.PipelineID, c.BuildID, c.StageID, stepID, report, c.repo(ctx), c.sha(ctx), c.CommitLink)
 	backoff := createBackoff(10 * 60 * time.Second)
-	_, err := c.retry(ctx, c.Endpoint+path, "POST", c.Sha, &tests, nil, false, false, backoff) //nolint:bodyclose
+	_, err := c.retry(ctx, c.url(ctx, path), "POST", c.sha(ctx), &results, nil, false, false, backoff) //nolint:bodyclose
+	c.audit(ctx, "write_benchmarks", path, stepID, results, err)
 	return err
 }
 
@@ -197,9 +401,37 @@ func (c *HTTPClient) DownloadLink(ctx context.Context, language, os, arch, frame
 	if err := c.validateDownloadLinkArgs(language); err != nil {
 		return resp, err
 	}
+	var cacheKey string
+	if c.linkCache != nil {
+		cacheKey = c.linkCache.key(c.url(ctx, ""), c.AccountID, language, os, arch, framework, version, env)
+		if links, ok := c.linkCache.get(cacheKey); ok {
+			return links, nil
+		}
+	}
 	path := fmt.Sprintf(agentEndpoint, c.AccountID, language, os, arch, framework, version, env)
 	backoff := createBackoff(5 * 60 * time.Second)
-	_, err := c.retry(ctx, c.Endpoint+path, "GET", "", nil, &resp, false, true, backoff) //nolint:bodyclose
+	_, err := c.retry(ctx, c.url(ctx, path), "GET", "", nil, &resp, false, true, backoff) //nolint:bodyclose
+	if err == nil && c.linkCache != nil {
+		if cerr := c.linkCache.put(cacheKey, resp); cerr != nil {
+			c.logger().Warnf("could not cache agent download links: %s", cerr)
+		}
+	}
+	return resp, err
+}
+
+// CheckAgentCompatibility reports whether an agent version is supported by
+// the TI server, along with any deprecation warnings
+func (c *HTTPClient) CheckAgentCompatibility(ctx context.Context, in types.AgentCompatibilityReq) (types.AgentCompatibility, error) {
+	var resp types.AgentCompatibility
+	if err := c.validateDownloadLinkArgs(in.Language); err != nil {
+		return resp, err
+	}
+	if in.Version == "" {
+		return resp, fmt.Errorf("version is not set")
+	}
+	path := fmt.Sprintf(agentCompatEndpoint, c.AccountID)
+	backoff := createBackoff(60 * time.Second)
+	_, err := c.retry(ctx, c.url(ctx, path), "POST", "", &in, &resp, false, true, backoff) //nolint:bodyclose
 	return resp, err
 }
 
@@ -209,20 +441,118 @@ func (c *HTTPClient) SelectTests(ctx context.Context, stepID, source, target str
 	if err := c.validateSelectTestsArgs(stepID, source, target); err != nil {
 		return resp, err
 	}
-	path := fmt.Sprintf(testEndpoint, c.AccountID, c.OrgID, c.ProjectID, c.PipelineID, c.BuildID, c.StageID, stepID, c.Repo, c.Sha, source, target)
+	if in != nil && in.PR == nil && c.pr != nil {
+		req := *in
+		req.PR = c.pr
+		in = &req
+	}
+	var cacheKey string
+	if c.selectCache != nil {
+		cacheKey = c.selectCache.key(c.repo(ctx), c.sha(ctx), source, target, in)
+		if cached, ok := c.selectCache.get(cacheKey); ok {
+			return cached, nil
+		}
+	}
+	path := fmt.Sprintf(testEndpoint, c.AccountID, c.OrgID, c.ProjectID, c.PipelineID, c.BuildID, c.StageID, stepID, c.repo(ctx), c.sha(ctx), source, target)
+	path += parentQuery(c.ParentPipelineID, c.ParentBuildID)
 	backoff := createBackoff(10 * 60 * time.Second)
-	_, err := c.retry(ctx, c.Endpoint+path, "POST", c.Sha, in, &resp, false, false, backoff) //nolint:bodyclose
+	_, err := c.retry(ctx, c.url(ctx, path), "POST", c.sha(ctx), in, &resp, false, false, backoff) //nolint:bodyclose
+	if err == nil && in != nil {
+		resp.AddAlwaysRun(types.ParseTestRefs(in.TiConfig.Config.AlwaysRun))
+	}
+	if err == nil && c.selectCache != nil {
+		if cerr := c.selectCache.put(cacheKey, resp); cerr != nil {
+			c.logger().Warnf("could not cache test selection: %s", cerr)
+		}
+	}
 	return resp, err
 }
 
-// UploadCg uploads avro encoded callgraph to server
+// SubmitSelectTests submits a test selection request to be computed
+// asynchronously and returns a ticket to poll with GetSelectionTicket or
+// WaitForSelection. It suits requests with a very large number of changed
+// files, whose synchronous selection can take longer than the retry window
+// of SelectTests. Always-run tests of the request are not merged into the
+// result.
+func (c *HTTPClient) SubmitSelectTests(ctx context.Context, stepID, source, target string, in *types.SelectTestsReq) (types.SelectionTicket, error) {
+	var resp types.SelectionTicket
+	if err := c.validateSelectTestsArgs(stepID, source, target); err != nil {
+		return resp, err
+	}
+	if in != nil && in.PR == nil && c.pr != nil {
+		req := *in
+		req.PR = c.pr
+		in = &req
+	}
+	path := fmt.Sprintf(asyncSelectEndpoint, c.AccountID, c.OrgID, c.ProjectID, c.PipelineID, c.BuildID, c.StageID, stepID, c.repo(ctx), c.sha(ctx), source, target)
+	path += parentQuery(c.ParentPipelineID, c.ParentBuildID)
+	backoff := createBackoff(60 * time.Second)
+	_, err := c.retry(ctx, c.url(ctx, path), "POST", c.sha(ctx), in, &resp, false, true, backoff) //nolint:bodyclose
+	return resp, err
+}
+
+// GetSelectionTicket returns the status of an asynchronous test selection
+func (c *HTTPClient) GetSelectionTicket(ctx context.Context, ticketID string) (types.SelectionTicket, error) {
+	var resp types.SelectionTicket
+	if err := c.validateTiArgs(); err != nil {
+		return resp, err
+	}
+	if ticketID == "" {
+		return resp, fmt.Errorf("ticket id is not set")
+	}
+	path := fmt.Sprintf(selectTicketEndpoint, c.AccountID, ticketID)
+	backoff := createBackoff(60 * time.Second)
+	_, err := c.retry(ctx, c.url(ctx, path), "GET", "", nil, &resp, false, true, backoff) //nolint:bodyclose
+	return resp, err
+}
+
+// GetSelectionAudit returns the record of how the tests of a step were
+// selected: the inputs, the rules applied and the selection
+func (c *HTTPClient) GetSelectionAudit(ctx context.Context, stepID string) (types.SelectionAudit, error) {
+	var resp types.SelectionAudit
+	if err := c.validateWriteSavingsArgs(stepID); err != nil {
+		return resp, err
+	}
+	path := fmt.Sprintf(selectAuditEndpoint, c.AccountID, c.OrgID, c.ProjectID, c.PipelineID, c.BuildID, c.StageID, stepID)
+	backoff := createBackoff(60 * time.Second)
+	_, err := c.retry(ctx, c.url(ctx, path), "GET", "", nil, &resp, false, true, backoff) //nolint:bodyclose
+	return resp, err
+}
+
+// UploadCg uploads avro encoded callgraph to server. Concurrent uploads
+// for the same step and commit share the result of the first one.
 func (c *HTTPClient) UploadCg(ctx context.Context, stepID, source, target string, timeMs int64, cg []byte) error {
+	if err := c.checkWritable("uploadcg"); err != nil {
+		return err
+	}
 	if err := c.validateUploadCgArgs(stepID, source, target); err != nil {
 		return err
 	}
-	path := fmt.Sprintf(cgEndpoint, c.AccountID, c.OrgID, c.ProjectID, c.PipelineID, c.BuildID, c.StageID, stepID, c.Repo, c.Sha, source, target, timeMs)
+	cg, schema, err := c.prepareCg(cg)
+	if err != nil {
+		return err
+	}
+	if err := checkPayloadSize("uploadcg", &cg, c.maxUploadCgSize, "enable callgraph compression or upload the callgraph in chunks"); err != nil {
+		return err
+	}
+	ctx, _ = ensureCorrelationID(ctx)
+	// only identical uploads are shared, a different callgraph of the same
+	// step, such as a partial one or one of another repo, is sent on its own
+	key := fmt.Sprintf("step %s repo %s sha %s source %s target %s cg %x", stepID, c.repo(ctx), c.sha(ctx), source, target, sha256.Sum256(cg))
+	return c.uploads.do(ctx, key, c.failDupUploads, func() error {
+		return c.uploadCg(ctx, stepID, source, target, timeMs, cg, schema)
+	})
+}
+
+// uploadCg sends a prepared callgraph to the TI server.
+func (c *HTTPClient) uploadCg(ctx context.Context, stepID, source, target string, timeMs int64, cg []byte, schema types.SchemaVersion) error {
+	path := fmt.Sprintf(cgEndpoint, c.AccountID, c.OrgID, c.ProjectID, c.PipelineID, c.BuildID, c.StageID, stepID, c.repo(ctx), c.sha(ctx), source, target, timeMs)
+	if schema != "" {
+		path += "&schemaVersion=" + string(schema)
+	}
 	backoff := createBackoff(45 * 60 * time.Second)
-	_, err := c.retry(ctx, c.Endpoint+path, "POST", c.Sha, &cg, nil, false, true, backoff) //nolint:bodyclose
+	_, err := c.retry(withBodyDigest(ctx), c.url(ctx, path), "POST", c.sha(ctx), &cg, nil, false, true, backoff) //nolint:bodyclose
+	c.audit(ctx, "uploadcg", path, stepID, cg, err)
 	return err
 }
 
@@ -234,7 +564,19 @@ func (c *HTTPClient) GetTestTimes(ctx context.Context, stepID string, in *types.
 	}
 	path := fmt.Sprintf(getTestsTimesEndpoint, c.AccountID, c.OrgID, c.ProjectID, c.PipelineID, c.BuildID, c.StageID, stepID)
 	backoff := createBackoff(10 * 60 * time.Second)
-	_, err := c.retry(ctx, c.Endpoint+path, "POST", "", in, &resp, false, true, backoff) //nolint:bodyclose
+	_, err := c.retry(ctx, c.url(ctx, path), "POST", "", in, &resp, false, true, backoff) //nolint:bodyclose
+	return resp, err
+}
+
+// GetSuiteTimes gets test timing data aggregated per suite or class
+func (c *HTTPClient) GetSuiteTimes(ctx context.Context, stepID string, in *types.SuiteTimesReq) (types.SuiteTimesResp, error) {
+	var resp types.SuiteTimesResp
+	if err := c.validateGetTestTimesArgs(); err != nil {
+		return resp, err
+	}
+	path := fmt.Sprintf(suiteTimesEndpoint, c.AccountID, c.OrgID, c.ProjectID, c.PipelineID, c.BuildID, c.StageID, stepID)
+	backoff := createBackoff(10 * 60 * time.Second)
+	_, err := c.retry(ctx, c.url(ctx, path), "POST", "", in, &resp, false, true, backoff) //nolint:bodyclose
 	return resp, err
 }
 
@@ -244,9 +586,9 @@ func (c *HTTPClient) CommitInfo(ctx context.Context, stepID, branch string) (typ
 	if err := c.validateCommitInfoArgs(stepID, branch); err != nil {
 		return resp, err
 	}
-	path := fmt.Sprintf(commitInfoEndpoint, c.AccountID, c.OrgID, c.ProjectID, c.PipelineID, c.BuildID, c.StageID, stepID, c.Repo, branch)
+	path := fmt.Sprintf(commitInfoEndpoint, c.AccountID, c.OrgID, c.ProjectID, c.PipelineID, c.BuildID, c.StageID, stepID, c.repo(ctx), branch)
 	backoff := createBackoff(5 * 60 * time.Second)
-	_, err := c.retry(ctx, c.Endpoint+path, "GET", "", nil, &resp, false, true, backoff) //nolint:bodyclose
+	_, err := c.retry(ctx, c.url(ctx, path), "GET", "", nil, &resp, false, true, backoff) //nolint:bodyclose
 	return resp, err
 }
 
@@ -256,8 +598,21 @@ func (c *HTTPClient) MLSelectTests(ctx context.Context, stepID, mlKey, source, t
 	if err := c.validateMLSelectTestArgs(); err != nil {
 		return resp, err
 	}
-	path := fmt.Sprintf(mlSelectTestsEndpoint, c.AccountID, c.OrgID, c.ProjectID, c.PipelineID, c.BuildID, c.StageID, stepID, c.Repo, c.Sha, source, target, mlKey, c.CommitLink)
-	_, err := c.do(ctx, c.Endpoint+path, "POST", "", in, &resp) //nolint:bodyclose
+	if in != nil && in.PR == nil && c.pr != nil {
+		req := *in
+		req.PR = c.pr
+		in = &req
+	}
+	path := fmt.Sprintf(mlSelectTestsEndpoint, c.AccountID, c.OrgID, c.ProjectID, c.PipelineID, c.BuildID, c.StageID, stepID, c.repo(ctx), c.sha(ctx), source, target, mlKey, c.CommitLink)
+	path += parentQuery(c.ParentPipelineID, c.ParentBuildID)
+	timeout, budget := c.mlSelectLimits()
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+	backoff := createBackoff(budget)
+	_, err := c.retry(ctx, c.url(ctx, path), "POST", "", in, &resp, false, true, backoff) //nolint:bodyclose
+	if err == nil {
+		c.applyConfidenceThreshold(&resp)
+	}
 	return resp, err
 }
 
@@ -267,11 +622,15 @@ func (c *HTTPClient) Summary(ctx context.Context, summaryRequest types.SummaryRe
 		return resp, err
 	}
 
-	c.SetBasicArguments(&summaryRequest)
+	summaryRequest = summaryRequest.WithDefaults(c.Defaults())
+	if err := summaryRequest.ReportType.Validate(); err != nil {
+		return resp, err
+	}
 
 	path := fmt.Sprintf(summaryEndpoint, c.AccountID, summaryRequest.OrgID, summaryRequest.ProjectID, summaryRequest.PipelineID, summaryRequest.BuildID, summaryRequest.StageID, summaryRequest.StepID, summaryRequest.ReportType)
+	path += parentQuery(summaryRequest.ParentPipelineID, summaryRequest.ParentBuildID)
 	backoff := createBackoff(5 * 60 * time.Second)
-	_, err := c.retry(ctx, c.Endpoint+path, "GET", "", nil, &resp, false, true, backoff) //nolint:bodyclose
+	_, err := c.retry(ctx, c.url(ctx, path), "GET", "", nil, &resp, false, true, backoff) //nolint:bodyclose
 	return resp, err
 }
 
@@ -281,28 +640,262 @@ func (c *HTTPClient) GetTestCases(ctx context.Context, testCasesRequest types.Te
 		return resp, err
 	}
 
-	c.SetBasicArguments(&testCasesRequest.BasicInfo)
+	testCasesRequest = testCasesRequest.WithDefaults(c.Defaults())
+	if err := validateTestCasesRequest(&testCasesRequest); err != nil {
+		return resp, err
+	}
 
 	path := fmt.Sprintf(testCasesEndpoint, c.AccountID, testCasesRequest.BasicInfo.OrgID, testCasesRequest.BasicInfo.ProjectID, testCasesRequest.BasicInfo.PipelineID, testCasesRequest.BasicInfo.BuildID, testCasesRequest.BasicInfo.StageID, testCasesRequest.BasicInfo.StepID, testCasesRequest.BasicInfo.ReportType, testCasesRequest.TestCaseSearchTerm, testCasesRequest.Sort, testCasesRequest.Order, testCasesRequest.PageIndex, testCasesRequest.PageSize, testCasesRequest.SuiteName)
+	path += parentQuery(testCasesRequest.BasicInfo.ParentPipelineID, testCasesRequest.BasicInfo.ParentBuildID)
+	backoff := createBackoff(5 * 60 * time.Second)
+	_, err := c.retry(ctx, c.url(ctx, path), "GET", "", nil, &resp, false, true, backoff) //nolint:bodyclose
+	return resp, err
+}
+
+// ReprocessReport asks the server to re-ingest and re-summarize a report already uploaded for a step
+func (c *HTTPClient) ReprocessReport(ctx context.Context, stepID, report string) (types.ReprocessJob, error) {
+	var resp types.ReprocessJob
+	if err := c.checkWritable("reprocess"); err != nil {
+		return resp, err
+	}
+	if err := c.validateWriteArgs(stepID, report); err != nil {
+		return resp, err
+	}
+	ctx, _ = ensureCorrelationID(ctx)
+	path := fmt.Sprintf(reprocessEndpoint, c.AccountID, c.OrgID, c.ProjectID, c.PipelineID, c.BuildID, c.StageID, stepID, report)
+	_, err := c.do(ctx, c.url(ctx, path), "POST", "", nil, &resp) //nolint:bodyclose
+	c.audit(ctx, "reprocess_report", path, stepID, nil, err)
+	return resp, err
+}
+
+// GetReprocessJob returns the status of a report reprocessing job
+func (c *HTTPClient) GetReprocessJob(ctx context.Context, jobID string) (types.ReprocessJob, error) {
+	var resp types.ReprocessJob
+	if err := c.validateTiArgs(); err != nil {
+		return resp, err
+	}
+	if jobID == "" {
+		return resp, fmt.Errorf("job id is not set")
+	}
+	path := fmt.Sprintf(reprocessJobEndpoint, c.AccountID, jobID)
+	backoff := createBackoff(5 * 60 * time.Second)
+	_, err := c.retry(ctx, c.url(ctx, path), "GET", "", nil, &resp, false, true, backoff) //nolint:bodyclose
+	return resp, err
+}
+
+// GetTestOwners returns the teams owning the given test classes/files
+func (c *HTTPClient) GetTestOwners(ctx context.Context, stepID string, in *types.GetTestOwnersReq) (types.GetTestOwnersResp, error) {
+	var resp types.GetTestOwnersResp
+	if err := c.validateGetTestOwnersArgs(c.repo(ctx)); err != nil {
+		return resp, err
+	}
+	path := fmt.Sprintf(testOwnersEndpoint, c.AccountID, c.OrgID, c.ProjectID, c.PipelineID, c.BuildID, c.StageID, stepID, c.repo(ctx), c.sha(ctx))
+	backoff := createBackoff(5 * 60 * time.Second)
+	_, err := c.retry(ctx, c.url(ctx, path), "POST", "", in, &resp, false, true, backoff) //nolint:bodyclose
+	return resp, err
+}
+
+// GetTestGaps returns the changed source files and methods which are not covered by any test
+func (c *HTTPClient) GetTestGaps(ctx context.Context, stepID string, in *types.TestGapsReq) (types.TestGapsResp, error) {
+	var resp types.TestGapsResp
+	if err := c.validateGetTestGapsArgs(stepID, c.repo(ctx)); err != nil {
+		return resp, err
+	}
+	path := fmt.Sprintf(testGapsEndpoint, c.AccountID, c.OrgID, c.ProjectID, c.PipelineID, c.BuildID, c.StageID, stepID, c.repo(ctx), c.sha(ctx))
 	backoff := createBackoff(5 * 60 * time.Second)
-	_, err := c.retry(ctx, c.Endpoint+path, "GET", "", nil, &resp, false, true, backoff) //nolint:bodyclose
+	_, err := c.retry(ctx, c.url(ctx, path), "POST", c.sha(ctx), in, &resp, false, true, backoff) //nolint:bodyclose
+	return resp, err
+}
+
+// GetImpactedTests returns the tests impacted by changes to the given files
+// without creating a selection record for a build
+func (c *HTTPClient) GetImpactedTests(ctx context.Context, files []types.File) (types.ImpactedTestsResp, error) {
+	var resp types.ImpactedTestsResp
+	if err := c.validateRepoArgs(c.repo(ctx)); err != nil {
+		return resp, err
+	}
+	path := fmt.Sprintf(impactedTestsEndpoint, c.AccountID, c.OrgID, c.ProjectID, c.repo(ctx), c.sha(ctx))
+	backoff := createBackoff(60 * time.Second)
+	_, err := c.retry(ctx, c.url(ctx, path), "POST", "", &types.ImpactedTestsReq{Files: files}, &resp, false, true, backoff) //nolint:bodyclose
 	return resp, err
 }
 
-// WriteSavings writes time savings for a step/feature to TI server
+// GetRequirementCoverage returns the executed tests linked to the given
+// issues or requirements, for traceability reports
+func (c *HTTPClient) GetRequirementCoverage(ctx context.Context, in *types.RequirementCoverageReq) (types.RequirementCoverageResp, error) {
+	var resp types.RequirementCoverageResp
+	if err := c.validateRepoArgs(c.repo(ctx)); err != nil {
+		return resp, err
+	}
+	path := fmt.Sprintf(requirementsEndpoint, c.AccountID, c.OrgID, c.ProjectID, c.repo(ctx))
+	backoff := createBackoff(60 * time.Second)
+	_, err := c.retry(ctx, c.url(ctx, path), "POST", "", in, &resp, false, true, backoff) //nolint:bodyclose
+	return resp, err
+}
+
+// GetAlwaysRunTests returns the tests configured on the server to never be
+// skipped for the repository, to be merged with SelectTestsResp.AddAlwaysRun
+func (c *HTTPClient) GetAlwaysRunTests(ctx context.Context) ([]types.RunnableTest, error) {
+	var resp []types.RunnableTest
+	if err := c.validateRepoArgs(c.repo(ctx)); err != nil {
+		return resp, err
+	}
+	path := fmt.Sprintf(alwaysRunEndpoint, c.AccountID, c.OrgID, c.ProjectID, c.repo(ctx))
+	backoff := createBackoff(60 * time.Second)
+	_, err := c.retry(ctx, c.url(ctx, path), "GET", "", nil, &resp, false, true, backoff) //nolint:bodyclose
+	return resp, err
+}
+
+// ListMutingRules returns the muting rules of the repository
+func (c *HTTPClient) ListMutingRules(ctx context.Context) ([]types.MutingRule, error) {
+	var resp []types.MutingRule
+	if err := c.validateRepoArgs(c.repo(ctx)); err != nil {
+		return resp, err
+	}
+	path := fmt.Sprintf(mutingRulesEndpoint, c.AccountID, c.OrgID, c.ProjectID, c.repo(ctx))
+	backoff := createBackoff(5 * 60 * time.Second)
+	_, err := c.retry(ctx, c.url(ctx, path), "GET", "", nil, &resp, false, true, backoff) //nolint:bodyclose
+	return resp, err
+}
+
+// CreateMutingRule creates a muting rule for the repository
+func (c *HTTPClient) CreateMutingRule(ctx context.Context, rule types.MutingRule) (types.MutingRule, error) {
+	var resp types.MutingRule
+	if err := c.checkWritable("create_muting_rule"); err != nil {
+		return resp, err
+	}
+	if err := c.validateRepoArgs(c.repo(ctx)); err != nil {
+		return resp, err
+	}
+	if rule.Pattern == "" {
+		return resp, fmt.Errorf("muting rule pattern is not set")
+	}
+	ctx, _ = ensureCorrelationID(ctx)
+	path := fmt.Sprintf(mutingRulesEndpoint, c.AccountID, c.OrgID, c.ProjectID, c.repo(ctx))
+	_, err := c.do(ctx, c.url(ctx, path), "POST", "", &rule, &resp) //nolint:bodyclose
+	c.audit(ctx, "create_muting_rule", path, "", rule, err)
+	return resp, err
+}
+
+// DeleteMutingRule deletes the muting rule with the given id
+func (c *HTTPClient) DeleteMutingRule(ctx context.Context, id string) error {
+	if err := c.checkWritable("delete_muting_rule"); err != nil {
+		return err
+	}
+	if err := c.validateRepoArgs(c.repo(ctx)); err != nil {
+		return err
+	}
+	if id == "" {
+		return fmt.Errorf("muting rule id is not set")
+	}
+	ctx, _ = ensureCorrelationID(ctx)
+	path := fmt.Sprintf(mutingRuleEndpoint, c.AccountID, c.OrgID, c.ProjectID, c.repo(ctx), id)
+	_, err := c.do(ctx, c.url(ctx, path), "DELETE", "", nil, nil) //nolint:bodyclose
+	c.audit(ctx, "delete_muting_rule", path, "", id, err)
+	return err
+}
+
+// RegisterWebhook registers a webhook receiving TI events of the project
+func (c *HTTPClient) RegisterWebhook(ctx context.Context, hook types.Webhook) (types.Webhook, error) {
+	var resp types.Webhook
+	if err := c.checkWritable("register_webhook"); err != nil {
+		return resp, err
+	}
+	if err := c.validateProjectArgs(); err != nil {
+		return resp, err
+	}
+	if hook.URL == "" {
+		return resp, fmt.Errorf("webhook url is not set")
+	}
+	ctx, _ = ensureCorrelationID(ctx)
+	path := fmt.Sprintf(webhooksEndpoint, c.AccountID, c.OrgID, c.ProjectID)
+	_, err := c.do(ctx, c.url(ctx, path), "POST", "", &hook, &resp) //nolint:bodyclose
+	c.audit(ctx, "register_webhook", path, "", hook, err)
+	return resp, err
+}
+
+// ListWebhooks returns the webhooks registered for the project
+func (c *HTTPClient) ListWebhooks(ctx context.Context) ([]types.Webhook, error) {
+	var resp []types.Webhook
+	if err := c.validateProjectArgs(); err != nil {
+		return resp, err
+	}
+	path := fmt.Sprintf(webhooksEndpoint, c.AccountID, c.OrgID, c.ProjectID)
+	backoff := createBackoff(60 * time.Second)
+	_, err := c.retry(ctx, c.url(ctx, path), "GET", "", nil, &resp, false, true, backoff) //nolint:bodyclose
+	return resp, err
+}
+
+// DeleteWebhook deletes the webhook with the given id
+func (c *HTTPClient) DeleteWebhook(ctx context.Context, id string) error {
+	if err := c.checkWritable("delete_webhook"); err != nil {
+		return err
+	}
+	if err := c.validateProjectArgs(); err != nil {
+		return err
+	}
+	if id == "" {
+		return fmt.Errorf("webhook id is not set")
+	}
+	ctx, _ = ensureCorrelationID(ctx)
+	path := fmt.Sprintf(webhookEndpoint, c.AccountID, c.OrgID, c.ProjectID, id)
+	_, err := c.do(ctx, c.url(ctx, path), "DELETE", "", nil, nil) //nolint:bodyclose
+	c.audit(ctx, "delete_webhook", path, "", id, err)
+	return err
+}
+
+// WriteSavings writes time savings for a step/feature to TI server.
+// Inconsistent submissions are rejected, see types.ValidateSavings
 func (c *HTTPClient) WriteSavings(ctx context.Context, stepID string, featureName types.SavingsFeature, featureState types.IntelligenceExecutionState, timeTakenMs int64, savingsRequest types.SavingsRequest) error {
+	if err := c.checkWritable("write_savings"); err != nil {
+		return err
+	}
 	if err := c.validateWriteSavingsArgs(stepID); err != nil {
 		return err
 	}
+	if err := types.ValidateSavings(featureName, featureState, timeTakenMs, savingsRequest); err != nil {
+		return err
+	}
+	ctx, _ = ensureCorrelationID(ctx)
 	timeTakenMsStr := strconv.Itoa(int(timeTakenMs))
-	path := fmt.Sprintf(savingsEndpoint, c.AccountID, c.OrgID, c.ProjectID, c.PipelineID, c.BuildID, c.StageID, stepID, c.Repo, string(featureName), string(featureState), timeTakenMsStr)
-	_, err := c.do(ctx, c.Endpoint+path, "POST", "", savingsRequest, nil) //nolint:bodyclose
+	path := fmt.Sprintf(savingsEndpoint, c.AccountID, c.OrgID, c.ProjectID, c.PipelineID, c.BuildID, c.StageID, stepID, c.repo(ctx), string(featureName), string(featureState), timeTakenMsStr)
+	// savings use the same bounded backoff as Write but are also retried on
+	// 5xx responses since transient server errors would drop the data
+	backoff := createBackoff(10 * 60 * time.Second)
+	_, err := c.retry(ctx, c.url(ctx, path), "POST", "", savingsRequest, nil, false, true, backoff) //nolint:bodyclose
+	c.audit(ctx, "write_savings", path, stepID, savingsRequest, err)
 	return err
 }
 
+// ForecastSavings returns the savings projected by the server from enabling
+// TI, build cache or DLC, based on the recent history of the repository
+func (c *HTTPClient) ForecastSavings(ctx context.Context, in *types.SavingsForecastReq) (types.SavingsForecast, error) {
+	var resp types.SavingsForecast
+	if err := c.validateRepoArgs(c.repo(ctx)); err != nil {
+		return resp, err
+	}
+	path := fmt.Sprintf(forecastEndpoint, c.AccountID, c.OrgID, c.ProjectID, c.repo(ctx))
+	backoff := createBackoff(60 * time.Second)
+	_, err := c.retry(ctx, c.url(ctx, path), "POST", "", in, &resp, false, true, backoff) //nolint:bodyclose
+	return resp, err
+}
+
+// GetSavingsRollup returns the savings aggregated per project or pipeline
+// of the scope over the last window
+func (c *HTTPClient) GetSavingsRollup(ctx context.Context, scope types.SavingsScope, window time.Duration) (types.SavingsRollup, error) {
+	var resp types.SavingsRollup
+	if err := c.validateSavingsRollupArgs(scope, window); err != nil {
+		return resp, err
+	}
+	path := fmt.Sprintf(rollupEndpoint, c.AccountID, scope.OrgID, scope.ProjectID, scope.GroupBy, window.Milliseconds())
+	backoff := createBackoff(60 * time.Second)
+	_, err := c.retry(ctx, c.url(ctx, path), "GET", "", nil, &resp, false, true, backoff) //nolint:bodyclose
+	return resp, err
+}
+
 // Healthz pings the healthz endpoint
 func (c *HTTPClient) Healthz(ctx context.Context) error {
-	response, err := c.do(ctx, c.Endpoint+healthzEndpoint, "GET", "", nil, nil)
+	response, err := c.do(ctx, c.url(ctx, healthzEndpoint), "GET", "", nil, nil)
 	if err != nil {
 		return err
 	}
@@ -312,14 +905,65 @@ func (c *HTTPClient) Healthz(ctx context.Context) error {
 	return nil
 }
 
-func (c *HTTPClient) retry(ctx context.Context, method, path, sha string, in, out interface{}, isOpen, retryOnServerErrors bool, b backoff.BackOff) (*http.Response, error) {
-	for {
+// HealthzInfo pings the healthz endpoint and returns the server version,
+// region and capabilities from the info endpoint
+func (c *HTTPClient) HealthzInfo(ctx context.Context) (types.ServerInfo, error) {
+	var resp types.ServerInfo
+	if err := c.Healthz(ctx); err != nil {
+		return resp, err
+	}
+	_, err := c.do(ctx, c.url(ctx, infoEndpoint), "GET", "", nil, &resp) //nolint:bodyclose
+	return resp, err
+}
+
+func (c *HTTPClient) retry(ctx context.Context, path, method, sha string, in, out interface{}, isOpen, retryOnServerErrors bool, b backoff.BackOff) (*http.Response, error) {
+	ctx, end, err := c.begin(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer end()
+	// all attempts of a logical operation share one correlation ID
+	ctx, _ = ensureCorrelationID(ctx)
+	// stage the body once for all the attempts
+	staged, err := c.stage(ctx, in)
+	if err != nil {
+		return nil, err
+	}
+	if staged != nil {
+		defer staged.remove()
+		ctx = withStagedBody(ctx, staged)
+	}
+	stats := RetryStats{Method: method, URL: SanitizeURL(path)}
+	start := time.Now()
+	res, err := c.retryLoop(ctx, path, method, sha, in, out, isOpen, retryOnServerErrors, b, &stats)
+	stats.Elapsed = time.Since(start)
+	if res != nil {
+		stats.StatusCode = res.StatusCode
+	} else {
+		var e *Error
+		if errors.As(err, &e) {
+			stats.StatusCode = e.Code
+		}
+	}
+	stats.Err = err
+	c.reportRetryStats(ctx, &stats)
+	return res, err
+}
+
+func (c *HTTPClient) retryLoop(ctx context.Context, path, method, sha string, in, out interface{}, isOpen, retryOnServerErrors bool, b backoff.BackOff, stats *RetryStats) (*http.Response, error) {
+	// TLS configuration errors are permanent, don't retry them
+	if _, err := c.httpClient(); err != nil {
+		return nil, err
+	}
+	for attempt := 1; ; attempt++ {
+		ctx := withAttempt(ctx, attempt)
+		stats.Attempts = attempt
 		var res *http.Response
 		var err error
 		if !isOpen {
-			res, err = c.do(ctx, method, path, sha, in, out)
+			res, err = c.do(ctx, path, method, sha, in, out)
 		} else {
-			res, err = c.open(ctx, method, path, in.(io.Reader))
+			res, err = c.open(ctx, path, method, in.(io.Reader))
 		}
 
 		// do not retry on Canceled or DeadlineExceeded
@@ -330,57 +974,108 @@ func (c *HTTPClient) retry(ctx context.Context, method, path, sha string, in, ou
 
 		duration := b.NextBackOff()
 
-		if res != nil {
+		retryable := false
+		if c.shouldRetry != nil {
+			retryable = err != nil && c.shouldRetry(res, err, attempt)
+		} else if res != nil {
 			// Check the response code. We retry on 5xx-range
 			// responses to allow the server time to recover, as
 			// 5xx's are typically not permanent errors and may
 			// relate to outages on the server side.
-			if res.StatusCode >= 500 && retryOnServerErrors {
-				// TI server error: Reconnect and retry
-				if duration == backoff.Stop {
-					return nil, err
-				}
-				time.Sleep(duration)
-				continue
-			}
+			retryable = res.StatusCode >= 500 && retryOnServerErrors
 		} else if err != nil {
 			// Request error: Retry
-			if duration == backoff.Stop {
-				return nil, err
-			}
-			time.Sleep(duration)
-			continue
+			retryable = true
+		}
+		if !retryable {
+			return res, withAttempts(err, attempt)
+		}
+		// TI server or request error: Reconnect and retry unless
+		// the backoff, the attempt limit or the retry budget is exhausted
+		if duration == backoff.Stop || !c.allowRetry(ctx, attempt) {
+			return nil, withAttempts(err, attempt)
+		}
+		select {
+		case <-ctx.Done():
+			return nil, withAttempts(err, attempt)
+		case <-c.life.abortedc():
+			return nil, withAttempts(err, attempt)
+		case <-time.After(duration):
+			stats.Backoff += duration
 		}
-		return res, err
 	}
 }
 
 // do is a helper function that posts a signed http request with
 // the input encoded and response decoded from json.
 func (c *HTTPClient) do(ctx context.Context, path, method, sha string, in, out interface{}) (*http.Response, error) { //nolint:unparam
+	ctx, end, err := c.begin(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer end()
 	var r io.Reader
+	var reqBody []byte
+	ctx, correlationID := ensureCorrelationID(ctx)
 
-	if in != nil {
+	hc, err := c.httpClient()
+	if err != nil {
+		return nil, err
+	}
+	var digest string
+	staged := stagedBodyFrom(ctx)
+	if staged == nil {
+		if staged, err = c.stage(ctx, in); err != nil {
+			return nil, err
+		} else if staged != nil {
+			defer staged.remove()
+		}
+	}
+	if staged != nil {
+		f, err := staged.open()
+		if err != nil {
+			return nil, err
+		}
+		r, digest = f, staged.digest
+	} else if in != nil {
 		buf := new(bytes.Buffer)
 		if err := json.NewEncoder(buf).Encode(in); err != nil {
 			return nil, err
 		}
+		reqBody = buf.Bytes()
 		r = buf
 	}
 
 	req, err := http.NewRequestWithContext(ctx, method, path, r)
 	if err != nil {
+		if rc, ok := r.(io.Closer); ok {
+			rc.Close()
+		}
 		return nil, err
 	}
 
 	// the request should include the secret shared between
 	// the agent and server for authorization.
-	req.Header.Add("X-Harness-Token", c.Token)
+	c.setHeaders(req)
+	if staged != nil {
+		staged.prepare(req)
+	} else {
+		digest = setBodyDigest(ctx, req, reqBody)
+	}
 	// adding sha as request-id for logging context
 	if sha != "" {
 		req.Header.Add("X-Request-ID", sha)
 	}
-	res, err := c.client().Do(req)
+	debug := c.debugEnabled()
+	if debug {
+		c.logger().Debugf("ti request: method=%s url=%s attempt=%d correlation_id=%s body=%s",
+			method, SanitizeURL(path), attemptFrom(ctx), correlationID, truncateBody(reqBody))
+	}
+	req, reportTimings := c.traceRequest(req)
+	start := time.Now()
+	res, err := hc.Do(req)
+	err = sanitizeError(err)
+	reportTimings()
 	if res != nil {
 		defer func() {
 			// drain the response body so we can reuse
@@ -391,6 +1086,23 @@ func (c *HTTPClient) do(ctx context.Context, path, method, sha string, in, out i
 		}()
 	}
 	if err != nil {
+		if debug {
+			c.logger().Debugf("ti response: method=%s url=%s attempt=%d latency=%s error=%s",
+				method, SanitizeURL(path), attemptFrom(ctx), time.Since(start), err)
+		}
+		if res != nil {
+			return res, err
+		}
+		return res, &Error{
+			Method:        method,
+			URL:           SanitizeURL(path),
+			RequestID:     sha,
+			CorrelationID: correlationID,
+			Err:           err,
+		}
+	}
+	c.observeClockSkew(res)
+	if err := c.checkBodyDigest(res, digest); err != nil {
 		return res, err
 	}
 
@@ -398,11 +1110,30 @@ func (c *HTTPClient) do(ctx context.Context, path, method, sha string, in, out i
 	// immediately. We do not read or unmarshal the response
 	// and we do not return an error.
 	if res.StatusCode == http.StatusNoContent {
+		if debug {
+			c.logger().Debugf("ti response: method=%s url=%s attempt=%d status=%d latency=%s",
+				method, SanitizeURL(path), attemptFrom(ctx), res.StatusCode, time.Since(start))
+		}
 		return res, nil
 	}
 
-	// else read the response body into a byte slice.
-	body, err := io.ReadAll(res.Body)
+	// else read the response body into a byte slice. Error
+	// bodies are capped since they may be large HTML pages.
+	var body []byte
+	truncated := false
+	if res.StatusCode >= http.StatusMultipleChoices {
+		limit := c.errorBodyLimit()
+		body, err = io.ReadAll(io.LimitReader(res.Body, limit+1))
+		if int64(len(body)) > limit {
+			body, truncated = body[:limit], true
+		}
+	} else {
+		body, err = io.ReadAll(res.Body)
+	}
+	if debug {
+		c.logger().Debugf("ti response: method=%s url=%s attempt=%d status=%d latency=%s body=%s",
+			method, SanitizeURL(path), attemptFrom(ctx), res.StatusCode, time.Since(start), truncateBody(body))
+	}
 	if err != nil {
 		return res, err
 	}
@@ -410,42 +1141,97 @@ func (c *HTTPClient) do(ctx context.Context, path, method, sha string, in, out i
 	if res.StatusCode >= http.StatusMultipleChoices {
 		// if the response body includes an error message
 		// we should return the error string.
+		e := &Error{
+			Code:          res.StatusCode,
+			Message:       string(body),
+			Method:        method,
+			URL:           SanitizeURL(path),
+			RequestID:     sha,
+			ContentType:   res.Header.Get("Content-Type"),
+			Snippet:       string(body),
+			Truncated:     truncated,
+			CorrelationID: correlationID,
+		}
+		if id := res.Header.Get("X-Request-ID"); id != "" {
+			e.RequestID = id
+		}
 		if len(body) != 0 {
-			out := new(Error)
+			out := new(serverError)
 			if err := json.Unmarshal(body, out); err == nil {
-				return res, &Error{Code: res.StatusCode, Message: out.Message}
+				e.Message = out.Message
+				e.ServerCode = out.code()
 			}
-			return res, &Error{Code: res.StatusCode, Message: string(body)}
+			return res, e
 		}
 		// if the response body is empty we should return
 		// the default status code text.
-		return res, errors.New(
-			http.StatusText(res.StatusCode),
-		)
+		e.Message = http.StatusText(res.StatusCode)
+		return res, e
 	}
 	if out == nil {
 		return res, nil
 	}
-	return res, json.Unmarshal(body, out)
+	return res, c.decode(res, body, out)
 }
 
-// client is a helper function that returns the default client
-// if a custom client is not defined.
-func (c *HTTPClient) client() *http.Client {
-	if c.Client == nil {
-		return defaultClient
+// url returns the full URL of the given endpoint path.
+func (c *HTTPClient) url(ctx context.Context, path string) string {
+	return c.endpoint(ctx) + c.basePath + path
+}
+
+// errorBodyLimit returns the maximum number of error body bytes captured.
+func (c *HTTPClient) errorBodyLimit() int64 {
+	if c.maxErrorBody <= 0 {
+		return defaultErrorBodyLimit
 	}
-	return c.Client
+	return c.maxErrorBody
 }
 
+// client is a helper function that returns the default client
+// if a custom client is not defined.
 // helper function to open an http request
 func (c *HTTPClient) open(ctx context.Context, path, method string, body io.Reader) (*http.Response, error) {
+	ctx, end, err := c.begin(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer end()
+	ctx, _ = ensureCorrelationID(ctx)
 	req, err := http.NewRequestWithContext(ctx, method, path, body)
 	if err != nil {
 		return nil, err
 	}
+	hc, err := c.httpClient()
+	if err != nil {
+		return nil, err
+	}
+	c.setHeaders(req)
+	req, reportTimings := c.traceRequest(req)
+	defer reportTimings()
+	res, err := hc.Do(req)
+	return res, sanitizeError(err)
+}
+
+// setHeaders adds the headers common to every request.
+func (c *HTTPClient) setHeaders(req *http.Request) {
 	req.Header.Add("X-Harness-Token", c.Token)
-	return c.client().Do(req)
+	if c.apiVersion != "" {
+		req.Header.Set(apiVersionHeader, c.apiVersion)
+	}
+	if id := CorrelationID(req.Context()); id != "" {
+		req.Header.Set(correlationIDHeader, id)
+	}
+	setTraceHeaders(req)
+	if c.pr != nil {
+		if b, err := json.Marshal(c.pr); err == nil {
+			req.Header.Set(prHeader, base64.StdEncoding.EncodeToString(b))
+		}
+	}
+	if c.userAgent == "" {
+		req.Header.Set("User-Agent", userAgent(""))
+	} else {
+		req.Header.Set("User-Agent", c.userAgent)
+	}
 }
 
 func createInfiniteBackoff() *backoff.ExponentialBackOff {
@@ -592,6 +1378,80 @@ func (c *HTTPClient) validateGetTestTimesArgs() error {
 	return c.validateBasicArgs()
 }
 
+func (c *HTTPClient) validateGetTestOwnersArgs(repo string) error {
+	if err := c.validateTiArgs(); err != nil {
+		return err
+	}
+	if err := c.validateBasicArgs(); err != nil {
+		return err
+	}
+	if repo == "" {
+		return fmt.Errorf("repo is not set")
+	}
+	return nil
+}
+
+func (c *HTTPClient) validateGetTestGapsArgs(stepID, repo string) error {
+	if err := c.validateTiArgs(); err != nil {
+		return err
+	}
+	if err := c.validateBasicArgs(); err != nil {
+		return err
+	}
+	if stepID == "" {
+		return fmt.Errorf("stepID is not set")
+	}
+	if repo == "" {
+		return fmt.Errorf("repo is not set")
+	}
+	return nil
+}
+
+func (c *HTTPClient) validateSavingsRollupArgs(scope types.SavingsScope, window time.Duration) error {
+	if err := c.validateTiArgs(); err != nil {
+		return err
+	}
+	if c.AccountID == "" {
+		return fmt.Errorf("accountID is not set")
+	}
+	if scope.ProjectID != "" && scope.OrgID == "" {
+		return fmt.Errorf("orgID is not set")
+	}
+	if scope.GroupBy != types.RollupByProject && scope.GroupBy != types.RollupByPipeline {
+		return fmt.Errorf("unknown rollup level %q", scope.GroupBy)
+	}
+	if window <= 0 {
+		return fmt.Errorf("rollup window must be positive")
+	}
+	return nil
+}
+
+func (c *HTTPClient) validateProjectArgs() error {
+	if err := c.validateTiArgs(); err != nil {
+		return err
+	}
+	if c.AccountID == "" {
+		return fmt.Errorf("accountID is not set")
+	}
+	if c.OrgID == "" {
+		return fmt.Errorf("orgID is not set")
+	}
+	if c.ProjectID == "" {
+		return fmt.Errorf("projectID is not set")
+	}
+	return nil
+}
+
+func (c *HTTPClient) validateRepoArgs(repo string) error {
+	if err := c.validateProjectArgs(); err != nil {
+		return err
+	}
+	if repo == "" {
+		return fmt.Errorf("repo is not set")
+	}
+	return nil
+}
+
 func (c *HTTPClient) validateCommitInfoArgs(stepID, branch string) error {
 	if err := c.validateTiArgs(); err != nil {
 		return err
@@ -621,25 +1481,48 @@ func (c *HTTPClient) validateMLSelectTestArgs() error {
 	return c.validateBasicArgs()
 }
 
-func (c *HTTPClient) SetBasicArguments(summaryRequest *types.SummaryRequest) {
-	if summaryRequest.OrgID == "" {
-		summaryRequest.OrgID = c.OrgID
-	}
-	if summaryRequest.ProjectID == "" {
-		summaryRequest.ProjectID = c.ProjectID
-	}
-	if summaryRequest.PipelineID == "" {
-		summaryRequest.PipelineID = c.PipelineID
+func validateTestCasesRequest(req *types.TestCasesRequest) error {
+	if err := req.BasicInfo.ReportType.Validate(); err != nil {
+		return err
 	}
-	if summaryRequest.BuildID == "" {
-		summaryRequest.BuildID = c.BuildID
+	if err := req.Sort.Validate(); err != nil {
+		return err
 	}
-	if summaryRequest.ReportType == "" {
-		summaryRequest.ReportType = "junit"
+	return req.Order.Validate()
+}
+
+// Defaults returns the identifiers the client was created with, to be
+// passed to the WithDefaults methods of read requests.
+func (c *HTTPClient) Defaults() types.BasicInfo {
+	return types.BasicInfo{
+		OrgID:            c.OrgID,
+		ProjectID:        c.ProjectID,
+		PipelineID:       c.PipelineID,
+		BuildID:          c.BuildID,
+		ParentPipelineID: c.ParentPipelineID,
+		ParentBuildID:    c.ParentBuildID,
 	}
+}
 
-	if summaryRequest.AllStages {
-		summaryRequest.StageID = ""
-		summaryRequest.StepID = ""
+// FillDefaults fills the identifiers missing from info with the ones the
+// client was created with.
+func (c *HTTPClient) FillDefaults(info *types.BasicInfo) {
+	*info = info.WithDefaults(c.Defaults())
+}
+
+// parentQuery returns the query parameters identifying the parent pipeline
+// execution, if any.
+func parentQuery(pipelineID, buildID string) string {
+	if pipelineID == "" && buildID == "" {
+		return ""
 	}
+	return "&parentPipelineId=" + url.QueryEscape(pipelineID) + "&parentBuildId=" + url.QueryEscape(buildID)
+}
+
+// SetBasicArguments fills summaryRequest in place.
+//
+// Deprecated: use summaryRequest.WithDefaults(c.Defaults()), which leaves
+// the request unchanged.
+func (c *HTTPClient) SetBasicArguments(summaryRequest *types.SummaryRequest) {
+	*summaryRequest = summaryRequest.WithDefaults(c.Defaults())
 }
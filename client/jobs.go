@@ -0,0 +1,140 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/harness/ti-client/types"
+)
+
+// WaitForReprocess polls the reprocessing job every interval until it is
+// done or ctx is done. It returns an error if the job failed. interval
+// defaults to 5 seconds.
+func WaitForReprocess(ctx context.Context, c Reprocessor, jobID string, interval time.Duration) (types.ReprocessJob, error) {
+	if interval <= 0 {
+		interval = 5 * time.Second
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		job, err := c.GetReprocessJob(ctx, jobID)
+		if err != nil {
+			return job, err
+		}
+		if job.Status == types.JobFailed {
+			return job, fmt.Errorf("reprocessing job %s failed: %s", job.ID, job.Message)
+		}
+		if job.Status.Done() {
+			return job, nil
+		}
+		select {
+		case <-ctx.Done():
+			return job, ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+// WaitForSummaryOptions configures WaitForSummary.
+type WaitForSummaryOptions struct {
+	// Steps are the steps whose reports must be ingested. When empty, the
+	// summary of the request itself must report tests.
+	Steps []types.StepInfo
+	// Interval between polls. Defaults to 5 seconds.
+	Interval time.Duration
+	// Timeout bounds the wait on top of ctx. Defaults to 30 minutes, a
+	// negative timeout means none.
+	Timeout time.Duration
+}
+
+// WaitForSummary polls the summary of each expected step until all of them
+// report tests or suite failures, then returns the summary of req. Reporting steps use it so
+// that they don't race the ingestion of the reports uploaded by test steps.
+func WaitForSummary(ctx context.Context, c Client, req types.SummaryRequest, opts WaitForSummaryOptions) (types.SummaryResponse, error) {
+	interval := opts.Interval
+	if interval <= 0 {
+		interval = 5 * time.Second
+	}
+	timeout := opts.Timeout
+	if timeout == 0 {
+		timeout = 30 * time.Minute
+	}
+	if timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, timeout)
+		defer cancel()
+	}
+
+	pending := opts.Steps
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		var remaining []types.StepInfo
+		for _, step := range pending {
+			stepReq := req
+			stepReq.AllStages = false
+			stepReq.StageID, stepReq.StepID = step.Stage, step.Step
+			resp, err := c.Summary(ctx, stepReq)
+			if err != nil {
+				return resp, err
+			}
+			if !ingested(resp) {
+				remaining = append(remaining, step)
+			}
+		}
+		pending = remaining
+
+		if len(pending) == 0 {
+			resp, err := c.Summary(ctx, req)
+			if err != nil || ingested(resp) || len(opts.Steps) > 0 {
+				return resp, err
+			}
+		}
+		select {
+		case <-ctx.Done():
+			if len(pending) > 0 {
+				return types.SummaryResponse{}, fmt.Errorf("reports of %d steps not ingested: %w", len(pending), ctx.Err())
+			}
+			return types.SummaryResponse{}, ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+// ingested reports whether a summary shows an ingested report. A report
+// whose whole suite failed in setup has suite failures but no tests.
+func ingested(resp types.SummaryResponse) bool {
+	return resp.TotalTests+resp.SuiteFailures > 0
+}
+
+// WaitForSelection polls the selection ticket every interval until the
+// selection is computed or ctx is done. It returns an error if the
+// selection failed. interval defaults to 5 seconds.
+func WaitForSelection(ctx context.Context, c AsyncSelector, ticketID string, interval time.Duration) (types.SelectTestsResp, error) {
+	if interval <= 0 {
+		interval = 5 * time.Second
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		ticket, err := c.GetSelectionTicket(ctx, ticketID)
+		if err != nil {
+			return types.SelectTestsResp{}, err
+		}
+		if ticket.Status == types.JobFailed {
+			return types.SelectTestsResp{}, fmt.Errorf("test selection %s failed: %s", ticket.ID, ticket.Message)
+		}
+		if ticket.Status.Done() {
+			if ticket.Result == nil {
+				return types.SelectTestsResp{}, fmt.Errorf("test selection %s has no result", ticket.ID)
+			}
+			return *ticket.Result, nil
+		}
+		select {
+		case <-ctx.Done():
+			return types.SelectTestsResp{}, ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
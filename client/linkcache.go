@@ -0,0 +1,79 @@
+package client
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/harness/ti-client/types"
+)
+
+// WithDownloadLinkCache caches DownloadLink results in memory for ttl and,
+// if dir is not empty, on disk in dir so that other processes on the same
+// runner reuse them.
+func WithDownloadLinkCache(ttl time.Duration, dir string) Option {
+	return func(c *HTTPClient) {
+		c.linkCache = &linkCache{ttl: ttl, dir: dir, entries: map[string]linkCacheEntry{}}
+	}
+}
+
+type linkCacheEntry struct {
+	ExpiresAt time.Time            `json:"expires_at"`
+	Links     []types.DownloadLink `json:"links"`
+}
+
+// linkCache is a read-through cache of agent download links.
+type linkCache struct {
+	ttl time.Duration
+	dir string
+
+	mu      sync.Mutex
+	entries map[string]linkCacheEntry
+}
+
+// key returns the cache key of a DownloadLink call.
+func (l *linkCache) key(parts ...string) string {
+	sum := sha256.Sum256([]byte(strings.Join(parts, "\x00")))
+	return hex.EncodeToString(sum[:])
+}
+
+func (l *linkCache) get(key string) ([]types.DownloadLink, bool) {
+	now := time.Now()
+	l.mu.Lock()
+	entry, ok := l.entries[key]
+	l.mu.Unlock()
+	if ok && now.Before(entry.ExpiresAt) {
+		return entry.Links, true
+	}
+	if l.dir == "" {
+		return nil, false
+	}
+	data, err := os.ReadFile(filepath.Join(l.dir, key+".json"))
+	if err != nil || json.Unmarshal(data, &entry) != nil || !now.Before(entry.ExpiresAt) {
+		return nil, false
+	}
+	l.mu.Lock()
+	l.entries[key] = entry
+	l.mu.Unlock()
+	return entry.Links, true
+}
+
+func (l *linkCache) put(key string, links []types.DownloadLink) error {
+	entry := linkCacheEntry{ExpiresAt: time.Now().Add(l.ttl), Links: links}
+	l.mu.Lock()
+	l.entries[key] = entry
+	l.mu.Unlock()
+	if l.dir == "" {
+		return nil
+	}
+	data, err := json.Marshal(entry)
+	if err != nil {
+		return err
+	}
+	return writeFileAtomic(l.dir, key+".json", data)
+}
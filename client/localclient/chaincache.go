@@ -0,0 +1,208 @@
+package localclient
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/harness/ti-client/types/chrysalis"
+)
+
+const chainCacheFile = "ti-chains.json"
+
+// ChainCache is a persistent cache of chrysalis chains and tests, so that
+// warm runners can decide which tests to skip without a full callgraph
+// upload. Like the store of Client, it is kept in a JSON file inside its
+// directory, rewritten atomically on every change.
+//
+// Chains are identified by their path and checksum, so that the chains of
+// several versions of a file can be cached, and tests by their key and
+// path. Upserted entries expire after the TTL of the cache, if any.
+type ChainCache struct {
+	dir string
+	ttl time.Duration
+	now func() time.Time
+
+	mu    sync.Mutex
+	state *chainCacheState
+}
+
+// chainCacheState is everything persisted by a chain cache.
+type chainCacheState struct {
+	Chains []chrysalis.Chain `json:"chains"`
+	Tests  []chrysalis.Test  `json:"tests"`
+}
+
+// OpenChainCache returns the chain cache stored in dir, whose upserted
+// entries expire after ttl. Entries never expire if ttl is 0, unless they
+// carry their own expiry.
+func OpenChainCache(dir string, ttl time.Duration) (*ChainCache, error) {
+	s := &chainCacheState{}
+	if err := readJSON(dir, chainCacheFile, s); err != nil {
+		return nil, fmt.Errorf("could not load chain cache: %w", err)
+	}
+	return &ChainCache{dir: dir, ttl: ttl, now: time.Now, state: s}, nil
+}
+
+// update applies fn to the state and persists it.
+func (c *ChainCache) update(fn func(s *chainCacheState)) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	fn(c.state)
+	return writeJSON(c.dir, chainCacheFile, c.state)
+}
+
+// expireAt returns the expiry of an entry upserted now, or the one it
+// already carries if the cache has no TTL.
+func (c *ChainCache) expireAt(current *time.Time) *time.Time {
+	if c.ttl <= 0 {
+		return current
+	}
+	t := c.now().Add(c.ttl)
+	return &t
+}
+
+// UpsertChains adds the chains to the cache, replacing the cached chains
+// with the same path and checksum, and resets their expiry.
+func (c *ChainCache) UpsertChains(chains ...chrysalis.Chain) error {
+	return c.update(func(s *chainCacheState) {
+		type key struct{ path, checksum string }
+		index := make(map[key]int, len(s.Chains))
+		for i, ch := range s.Chains {
+			index[key{ch.Path, ch.Checksum}] = i
+		}
+		for _, ch := range chains {
+			ch.ExpireAt = c.expireAt(ch.ExpireAt)
+			k := key{ch.Path, ch.Checksum}
+			if i, ok := index[k]; ok {
+				s.Chains[i] = ch
+				continue
+			}
+			index[k] = len(s.Chains)
+			s.Chains = append(s.Chains, ch)
+		}
+	})
+}
+
+// UpsertTests adds the tests to the cache, replacing the cached tests with
+// the same key and path, and resets their expiry.
+func (c *ChainCache) UpsertTests(tests ...chrysalis.Test) error {
+	return c.update(func(s *chainCacheState) {
+		type key struct{ key, path string }
+		index := make(map[key]int, len(s.Tests))
+		for i, t := range s.Tests {
+			index[key{t.Key, t.Path}] = i
+		}
+		for _, t := range tests {
+			t.ExpireAt = c.expireAt(t.ExpireAt)
+			k := key{t.Key, t.Path}
+			if i, ok := index[k]; ok {
+				s.Tests[i] = t
+				continue
+			}
+			index[k] = len(s.Tests)
+			s.Tests = append(s.Tests, t)
+		}
+	})
+}
+
+// Chains returns the cached chains of the given paths which haven't
+// expired, in the order they were first cached.
+func (c *ChainCache) Chains(paths ...string) []chrysalis.Chain {
+	wanted := pathSet(paths)
+	now := c.now()
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	var chains []chrysalis.Chain
+	for _, ch := range c.state.Chains {
+		if wanted[ch.Path] && !ch.Expired(now) {
+			chains = append(chains, ch)
+		}
+	}
+	return chains
+}
+
+// Tests returns the cached tests defined in the given paths which haven't
+// expired, in the order they were first cached.
+func (c *ChainCache) Tests(paths ...string) []chrysalis.Test {
+	wanted := pathSet(paths)
+	now := c.now()
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	var tests []chrysalis.Test
+	for _, t := range c.state.Tests {
+		if wanted[t.Path] && !t.Expired(now) {
+			tests = append(tests, t)
+		}
+	}
+	return tests
+}
+
+// Expire removes the expired chains and tests from the cache and returns
+// their number.
+func (c *ChainCache) Expire() (int, error) {
+	now := c.now()
+	var removed int
+	err := c.update(func(s *chainCacheState) {
+		chains := s.Chains[:0]
+		for _, ch := range s.Chains {
+			if !ch.Expired(now) {
+				chains = append(chains, ch)
+			}
+		}
+		tests := s.Tests[:0]
+		for _, t := range s.Tests {
+			if !t.Expired(now) {
+				tests = append(tests, t)
+			}
+		}
+		removed = len(s.Chains) - len(chains) + len(s.Tests) - len(tests)
+		s.Chains, s.Tests = chains, tests
+	})
+	return removed, err
+}
+
+func pathSet(paths []string) map[string]bool {
+	set := make(map[string]bool, len(paths))
+	for _, p := range paths {
+		set[p] = true
+	}
+	return set
+}
+
+// Prune removes the expired chains and tests, the chains of source files
+// and the tests of test files which no longer exist in the workspace at
+// root, and returns the number of entries removed.
+func (c *ChainCache) Prune(root string) (int, error) {
+	now := c.now()
+	exists := make(map[string]bool)
+	present := func(p string) bool {
+		ok, seen := exists[p]
+		if !seen {
+			_, err := os.Stat(filepath.Join(root, filepath.FromSlash(p)))
+			ok = err == nil
+			exists[p] = ok
+		}
+		return ok
+	}
+	var removed int
+	err := c.update(func(s *chainCacheState) {
+		chains := s.Chains[:0]
+		for _, ch := range s.Chains {
+			if !ch.Expired(now) && present(ch.Path) {
+				chains = append(chains, ch)
+			}
+		}
+		tests := s.Tests[:0]
+		for _, t := range s.Tests {
+			if !t.Expired(now) && present(t.Path) {
+				tests = append(tests, t)
+			}
+		}
+		removed = len(s.Chains) - len(chains) + len(s.Tests) - len(tests)
+		s.Chains, s.Tests = chains, tests
+	})
+	return removed, err
+}
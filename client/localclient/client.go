@@ -0,0 +1,570 @@
+// Package localclient implements the TI client interface on top of a local
+// store, so that test intelligence can be tried without a TI service.
+//
+// Callgraphs, test reports and muting rules are kept in a single JSON file
+// inside the store directory and test selection is performed locally from
+// the uploaded callgraphs. The file is rewritten atomically on every
+// change. This keeps the module free of an embedded database dependency
+// such as bbolt or SQLite and suits the data of a single repository; it is
+// not meant for a shared, high write volume store. Features which need the TI service (ML based
+// selection, agent downloads, ownership, ...) return ErrNotSupported.
+package localclient
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/harness/ti-client/client"
+	"github.com/harness/ti-client/types"
+)
+
+var (
+	_ client.Client          = (*Client)(nil)
+	_ client.ResultWriter    = (*Client)(nil)
+	_ client.AsyncSelector   = (*Client)(nil)
+	_ client.TestAnalyzer    = (*Client)(nil)
+	_ client.Reprocessor     = (*Client)(nil)
+	_ client.Muter           = (*Client)(nil)
+	_ client.WebhookManager  = (*Client)(nil)
+	_ client.SavingsReporter = (*Client)(nil)
+	_ client.HealthReporter  = (*Client)(nil)
+	_ client.AgentChecker    = (*Client)(nil)
+)
+
+// ErrNotSupported is returned by the operations which need a TI service.
+var ErrNotSupported = errors.New("not supported by the local TI client")
+
+// CgDecoder decodes an uploaded callgraph into test chains.
+type CgDecoder func(cg []byte) ([]Chain, error)
+
+// Option configures optional behaviour of a Client.
+type Option func(*Client)
+
+// WithCgDecoder sets the decoder of uploaded callgraphs. By default
+// callgraphs are expected to be JSON encoded lists of Chain.
+func WithCgDecoder(d CgDecoder) Option {
+	return func(c *Client) {
+		c.decode = d
+	}
+}
+
+// Client is a TI client storing its data in a local directory.
+type Client struct {
+	dir    string
+	decode CgDecoder
+
+	mu    sync.Mutex
+	state *state
+}
+
+// New returns a client storing its data in dir.
+func New(dir string, opts ...Option) (*Client, error) {
+	s, err := load(dir)
+	if err != nil {
+		return nil, fmt.Errorf("could not load local TI store: %w", err)
+	}
+	c := &Client{dir: dir, state: s, decode: decodeJSON}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c, nil
+}
+
+func decodeJSON(cg []byte) ([]Chain, error) {
+	var chains []Chain
+	err := json.Unmarshal(cg, &chains)
+	return chains, err
+}
+
+// update applies fn to the state and persists it.
+func (c *Client) update(fn func(s *state) error) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if err := fn(c.state); err != nil {
+		return err
+	}
+	return c.state.save(c.dir)
+}
+
+// Write stores the test cases of a report of a step. A test case already
+// stored for the step, eg by a previous run of the step, is replaced, so
+// that reruns are not counted twice.
+func (c *Client) Write(ctx context.Context, step, report string, tests []*types.TestCase) error {
+	if step == "" {
+		return fmt.Errorf("stepID is not set")
+	}
+	types.MarkSuiteFailures(tests)
+	return c.update(func(s *state) error {
+		stored := s.Reports[step]
+		index := make(map[string]int, len(stored))
+		for i, t := range stored {
+			index[testKey(t)] = i
+		}
+		for _, t := range tests {
+			if i, ok := index[testKey(t)]; ok {
+				stored[i] = t
+				continue
+			}
+			index[testKey(t)] = len(stored)
+			stored = append(stored, t)
+		}
+		s.Reports[step] = stored
+		return nil
+	})
+}
+
+// testKey identifies a test case within the reports of a step.
+func testKey(t *types.TestCase) string {
+	return t.ClassName + "#" + t.Name + "#" + string(t.SuitePhase)
+}
+
+// WriteManualResults is not supported, only automated results are stored.
+func (c *Client) WriteManualResults(ctx context.Context, step, report string, results []*types.ManualTestResult) error {
+	return ErrNotSupported
+}
+
+// WriteBenchmarks is not supported, only automated results are stored.
+func (c *Client) WriteBenchmarks(ctx context.Context, step, report string, results []*types.BenchmarkResult) error {
+	return ErrNotSupported
+}
+
+// SelectTests selects the tests reaching the changed files.
+func (c *Client) SelectTests(ctx context.Context, step, source, target string, in *types.SelectTestsReq) (types.SelectTestsResp, error) {
+	if in == nil {
+		in = &types.SelectTestsReq{}
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	resp := selectTests(c.state.Chains, in)
+	resp.AddAlwaysRun(types.ParseTestRefs(in.TiConfig.Config.AlwaysRun))
+	return resp, nil
+}
+
+// SubmitSelectTests selects the tests right away and returns a completed
+// ticket.
+func (c *Client) SubmitSelectTests(ctx context.Context, stepID, source, target string, in *types.SelectTestsReq) (types.SelectionTicket, error) {
+	resp, err := c.SelectTests(ctx, stepID, source, target, in)
+	if err != nil {
+		return types.SelectionTicket{}, err
+	}
+	now := time.Now()
+	return types.SelectionTicket{ID: "local", Status: types.JobSucceeded, Result: &resp, CreatedAt: now, UpdatedAt: now}, nil
+}
+
+// GetSelectionTicket is not supported, tickets are completed on submission.
+func (c *Client) GetSelectionTicket(ctx context.Context, ticketID string) (types.SelectionTicket, error) {
+	return types.SelectionTicket{}, ErrNotSupported
+}
+
+// GetSelectionAudit is not supported, selections are not recorded.
+func (c *Client) GetSelectionAudit(ctx context.Context, stepID string) (types.SelectionAudit, error) {
+	return types.SelectionAudit{}, ErrNotSupported
+}
+
+// UploadCg merges the uploaded callgraph into the store.
+func (c *Client) UploadCg(ctx context.Context, step, source, target string, timeMs int64, cg []byte) error {
+	chains, err := c.decode(cg)
+	if err != nil {
+		return fmt.Errorf("could not decode callgraph: %w", err)
+	}
+	return c.update(func(s *state) error {
+		s.Chains = mergeChains(s.Chains, chains)
+		return nil
+	})
+}
+
+// PruneChains prunes the stored chains against the workspace at root, see
+// PruneChains, and returns the number of chains dropped.
+func (c *Client) PruneChains(root string) (int, error) {
+	var dropped int
+	err := c.update(func(s *state) error {
+		pruned := PruneChains(s.Chains, root)
+		dropped = len(s.Chains) - len(pruned)
+		s.Chains = pruned
+		return nil
+	})
+	return dropped, err
+}
+
+// DownloadLink is not supported.
+func (c *Client) DownloadLink(ctx context.Context, language, os, arch, framework, version, env string) ([]types.DownloadLink, error) {
+	return nil, ErrNotSupported
+}
+
+// CheckAgentCompatibility is not supported.
+func (c *Client) CheckAgentCompatibility(ctx context.Context, in types.AgentCompatibilityReq) (types.AgentCompatibility, error) {
+	return types.AgentCompatibility{}, ErrNotSupported
+}
+
+// GetTestTimes returns the durations recorded by all written reports.
+func (c *Client) GetTestTimes(ctx context.Context, step string, in *types.GetTestTimesReq) (types.GetTestTimesResp, error) {
+	if in == nil {
+		in = &types.GetTestTimesReq{}
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	resp := types.GetTestTimesResp{
+		FileTimeMap:  map[string]int{},
+		SuiteTimeMap: map[string]int{},
+		TestTimeMap:  map[string]int{},
+		ClassTimeMap: map[string]int{},
+	}
+	for _, tests := range c.state.Reports {
+		for _, t := range tests {
+			ms := int(t.DurationMs)
+			if in.IncludeFilename && t.FileName != "" {
+				resp.FileTimeMap[t.FileName] += ms
+			}
+			if in.IncludeTestSuite && t.SuiteName != "" {
+				resp.SuiteTimeMap[t.SuiteName] += ms
+			}
+			if in.IncludeTestCase {
+				resp.TestTimeMap[t.Name] += ms
+			}
+			if in.IncludeClassname && t.ClassName != "" {
+				resp.ClassTimeMap[t.ClassName] += ms
+			}
+		}
+	}
+	return resp, nil
+}
+
+// GetSuiteTimes aggregates the durations recorded by all written reports
+// per suite or class, sorted by name.
+func (c *Client) GetSuiteTimes(ctx context.Context, step string, in *types.SuiteTimesReq) (types.SuiteTimesResp, error) {
+	if in == nil {
+		return types.SuiteTimesResp{}, fmt.Errorf("suite times request is not set")
+	}
+	if in.Level != types.TimingBySuite && in.Level != types.TimingByClass {
+		return types.SuiteTimesResp{}, fmt.Errorf("unknown timing level %q", in.Level)
+	}
+	wanted := map[string]bool{}
+	for _, name := range in.Names {
+		wanted[name] = true
+	}
+	c.mu.Lock()
+	timings := map[string]*types.SuiteTiming{}
+	for _, tests := range c.state.Reports {
+		for _, t := range tests {
+			name := t.SuiteName
+			if in.Level == types.TimingByClass {
+				name = t.ClassName
+			}
+			if name == "" || (len(wanted) > 0 && !wanted[name]) {
+				continue
+			}
+			timing, ok := timings[name]
+			if !ok {
+				timing = &types.SuiteTiming{Name: name}
+				timings[name] = timing
+			}
+			timing.DurationMs += t.DurationMs
+			timing.Tests++
+		}
+	}
+	c.mu.Unlock()
+
+	resp := types.SuiteTimesResp{Level: in.Level}
+	for _, timing := range timings {
+		resp.Timings = append(resp.Timings, *timing)
+	}
+	sort.Slice(resp.Timings, func(i, j int) bool { return resp.Timings[i].Name < resp.Timings[j].Name })
+	return resp, nil
+}
+
+// CommitInfo is not supported, the local store doesn't track commits.
+func (c *Client) CommitInfo(ctx context.Context, stepID, branch string) (types.CommitInfoResp, error) {
+	return types.CommitInfoResp{}, ErrNotSupported
+}
+
+// MLSelectTests is not supported.
+func (c *Client) MLSelectTests(ctx context.Context, stepID, mlKey, source, target string, in *types.MLSelectTestsRequest) (types.SelectTestsResp, error) {
+	return types.SelectTestsResp{}, ErrNotSupported
+}
+
+// Summary summarizes the test cases written for the step, or for all the
+// steps if AllStages is set.
+func (c *Client) Summary(ctx context.Context, req types.SummaryRequest) (types.SummaryResponse, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	var resp types.SummaryResponse
+	var sampled float64
+	for _, t := range c.tests(req) {
+		if t.SampleWeight > 0 {
+			// a sampled passed test stands for SampleWeight tests
+			sampled += t.SampleWeight
+			resp.TimeMs += int64(t.SampleWeight * float64(t.DurationMs))
+			continue
+		}
+		if t.IsSuiteFailure() {
+			resp.SuiteFailures++
+			continue
+		}
+		resp.TotalTests++
+		resp.TimeMs += t.DurationMs
+		switch t.Result.Status {
+		case types.StatusFailed, types.StatusError:
+			switch {
+			case t.Muted:
+				resp.MutedTests++
+			case t.Quarantined:
+				resp.QuarantinedTests++
+			default:
+				resp.FailedTests++
+			}
+		case types.StatusSkipped:
+			resp.SkippedTests++
+		default:
+			resp.SuccessfulTests++
+		}
+	}
+	if n := int(math.Round(sampled)); n > 0 {
+		resp.TotalTests += n
+		resp.SuccessfulTests += n
+	}
+	return resp, nil
+}
+
+// GetTestCases returns a page of the test cases written for the step.
+func (c *Client) GetTestCases(ctx context.Context, req types.TestCasesRequest) (types.TestCases, error) {
+	if err := req.Sort.Validate(); err != nil {
+		return types.TestCases{}, err
+	}
+	if err := req.Order.Validate(); err != nil {
+		return types.TestCases{}, err
+	}
+	c.mu.Lock()
+	var tests []types.TestCase
+	for _, t := range c.tests(req.BasicInfo) {
+		if req.SuiteName != "" && t.SuiteName != req.SuiteName {
+			continue
+		}
+		if term := req.TestCaseSearchTerm; term != "" && !strings.Contains(t.Name, term) && !strings.Contains(t.ClassName, term) {
+			continue
+		}
+		tests = append(tests, *t)
+	}
+	c.mu.Unlock()
+
+	sortTests(tests, req.Sort, req.Order)
+	index, _ := strconv.Atoi(req.PageIndex)
+	if index < 0 {
+		index = 0
+	}
+	size, err := strconv.Atoi(req.PageSize)
+	if err != nil || size <= 0 {
+		size = len(tests)
+		if size == 0 {
+			size = 1
+		}
+	}
+	start, end := index*size, (index+1)*size
+	if start > len(tests) {
+		start = len(tests)
+	}
+	if end > len(tests) {
+		end = len(tests)
+	}
+	page := tests[start:end]
+	return types.TestCases{
+		Metadata: types.ResponseMetadata{
+			TotalPages:    (len(tests) + size - 1) / size,
+			TotalItems:    len(tests),
+			PageItemCount: len(page),
+			PageSize:      size,
+		},
+		Tests: page,
+	}, nil
+}
+
+// tests returns the stored test cases matching req. c.mu must be held.
+func (c *Client) tests(req types.SummaryRequest) []*types.TestCase {
+	if !req.AllStages {
+		return c.state.Reports[req.StepID]
+	}
+	var steps []string
+	for step := range c.state.Reports {
+		steps = append(steps, step)
+	}
+	sort.Strings(steps)
+	var out []*types.TestCase
+	for _, step := range steps {
+		out = append(out, c.state.Reports[step]...)
+	}
+	return out
+}
+
+func sortTests(tests []types.TestCase, field types.SortField, order types.SortOrder) {
+	key := func(t *types.TestCase) string {
+		switch field {
+		case types.SortClassName:
+			return t.ClassName
+		case types.SortSuiteName:
+			return t.SuiteName
+		case types.SortStatus:
+			return string(t.Result.Status)
+		}
+		return t.Name
+	}
+	less := func(i, j int) bool {
+		if field == types.SortDurationMs {
+			return tests[i].DurationMs < tests[j].DurationMs
+		}
+		return key(&tests[i]) < key(&tests[j])
+	}
+	if order == types.OrderDesc {
+		sort.SliceStable(tests, func(i, j int) bool { return less(j, i) })
+		return
+	}
+	sort.SliceStable(tests, less)
+}
+
+// Healthz always succeeds.
+func (c *Client) Healthz(ctx context.Context) error {
+	return nil
+}
+
+// HealthzInfo describes the local client.
+func (c *Client) HealthzInfo(ctx context.Context) (types.ServerInfo, error) {
+	return types.ServerInfo{Version: "local"}, nil
+}
+
+// ReprocessReport is not supported.
+func (c *Client) ReprocessReport(ctx context.Context, stepID, report string) (types.ReprocessJob, error) {
+	return types.ReprocessJob{}, ErrNotSupported
+}
+
+// GetReprocessJob is not supported.
+func (c *Client) GetReprocessJob(ctx context.Context, jobID string) (types.ReprocessJob, error) {
+	return types.ReprocessJob{}, ErrNotSupported
+}
+
+// GetTestOwners is not supported.
+func (c *Client) GetTestOwners(ctx context.Context, stepID string, in *types.GetTestOwnersReq) (types.GetTestOwnersResp, error) {
+	return types.GetTestOwnersResp{}, ErrNotSupported
+}
+
+// GetTestGaps is not supported.
+func (c *Client) GetTestGaps(ctx context.Context, stepID string, in *types.TestGapsReq) (types.TestGapsResp, error) {
+	return types.TestGapsResp{}, ErrNotSupported
+}
+
+// GetImpactedTests returns the tests reaching the changed files.
+func (c *Client) GetImpactedTests(ctx context.Context, files []types.File) (types.ImpactedTestsResp, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	resp := selectTests(c.state.Chains, &types.SelectTestsReq{Files: files})
+	return types.ImpactedTestsResp{SelectAll: resp.SelectAll, Tests: resp.Tests}, nil
+}
+
+// GetRequirementCoverage returns the stored tests linked to the requested
+// issues, sorted by issue.
+func (c *Client) GetRequirementCoverage(ctx context.Context, in *types.RequirementCoverageReq) (types.RequirementCoverageResp, error) {
+	if in == nil {
+		in = &types.RequirementCoverageReq{}
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	wanted := map[string]bool{}
+	for _, issue := range in.Issues {
+		wanted[issue] = true
+	}
+	tests := map[string][]types.TestSummary{}
+	for _, report := range c.state.Reports {
+		for _, t := range report {
+			for _, issue := range t.Issues {
+				if len(wanted) == 0 || wanted[issue] {
+					tests[issue] = append(tests[issue], types.TestSummary{Name: t.Name, Status: t.Result.Status})
+				}
+			}
+		}
+	}
+	issues := make([]string, 0, len(tests))
+	for issue := range tests {
+		issues = append(issues, issue)
+	}
+	sort.Strings(issues)
+	var resp types.RequirementCoverageResp
+	for _, issue := range issues {
+		resp.Requirements = append(resp.Requirements, types.RequirementCoverage{Issue: issue, Tests: tests[issue]})
+	}
+	return resp, nil
+}
+
+// GetAlwaysRunTests returns no tests, the local client only applies the
+// always-run tests of the repository config.
+func (c *Client) GetAlwaysRunTests(ctx context.Context) ([]types.RunnableTest, error) {
+	return nil, nil
+}
+
+// ListMutingRules returns the stored muting rules.
+func (c *Client) ListMutingRules(ctx context.Context) ([]types.MutingRule, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return append([]types.MutingRule(nil), c.state.MutingRules...), nil
+}
+
+// CreateMutingRule stores a muting rule.
+func (c *Client) CreateMutingRule(ctx context.Context, rule types.MutingRule) (types.MutingRule, error) {
+	if rule.Pattern == "" {
+		return rule, fmt.Errorf("pattern is not set")
+	}
+	err := c.update(func(s *state) error {
+		s.NextRuleID++
+		rule.ID = strconv.Itoa(s.NextRuleID)
+		s.MutingRules = append(s.MutingRules, rule)
+		return nil
+	})
+	return rule, err
+}
+
+// DeleteMutingRule deletes the muting rule with the given id.
+func (c *Client) DeleteMutingRule(ctx context.Context, id string) error {
+	return c.update(func(s *state) error {
+		for i, r := range s.MutingRules {
+			if r.ID == id {
+				s.MutingRules = append(s.MutingRules[:i], s.MutingRules[i+1:]...)
+				return nil
+			}
+		}
+		return fmt.Errorf("muting rule %s not found", id)
+	})
+}
+
+// RegisterWebhook is not supported.
+func (c *Client) RegisterWebhook(ctx context.Context, hook types.Webhook) (types.Webhook, error) {
+	return types.Webhook{}, ErrNotSupported
+}
+
+// ListWebhooks is not supported.
+func (c *Client) ListWebhooks(ctx context.Context) ([]types.Webhook, error) {
+	return nil, ErrNotSupported
+}
+
+// DeleteWebhook is not supported.
+func (c *Client) DeleteWebhook(ctx context.Context, id string) error {
+	return ErrNotSupported
+}
+
+// WriteSavings is a no-op, savings are only tracked by the TI service.
+func (c *Client) WriteSavings(ctx context.Context, stepID string, featureName types.SavingsFeature, featureState types.IntelligenceExecutionState, timeTakenMs int64, savingsRequest types.SavingsRequest) error {
+	return nil
+}
+
+// ForecastSavings is not supported, forecasts are computed by the TI service.
+func (c *Client) ForecastSavings(ctx context.Context, in *types.SavingsForecastReq) (types.SavingsForecast, error) {
+	return types.SavingsForecast{}, ErrNotSupported
+}
+
+// GetSavingsRollup is not supported, savings are only tracked by the TI service.
+func (c *Client) GetSavingsRollup(ctx context.Context, scope types.SavingsScope, window time.Duration) (types.SavingsRollup, error) {
+	return types.SavingsRollup{}, ErrNotSupported
+}
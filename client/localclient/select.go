@@ -0,0 +1,116 @@
+package localclient
+
+import (
+	"os"
+	"path"
+	"path/filepath"
+
+	"github.com/harness/ti-client/types"
+)
+
+// Chain maps a test to the source files its execution reaches.
+type Chain struct {
+	Test    types.RunnableTest `json:"test"`
+	Sources []string           `json:"sources"`
+}
+
+// selectTests picks the tests whose chains reach one of the changed files.
+// All the tests are selected when there is no callgraph yet, when
+// dependencies changed, or when a changed test file is not known to the
+// callgraph, as its tests can't be described without one.
+func selectTests(chains []Chain, in *types.SelectTestsReq) types.SelectTestsResp {
+	resp := types.SelectTestsResp{TotalTests: len(chains)}
+	if in.SelectAll || len(chains) == 0 || len(in.ChangedDependencies) > 0 {
+		resp.SelectAll = true
+		return resp
+	}
+
+	bySource := make(map[string][]int)
+	for i, c := range chains {
+		for _, src := range c.Sources {
+			bySource[src] = append(bySource[src], i)
+		}
+	}
+	selected := make(map[int]bool)
+	for _, f := range in.Files {
+		idx, ok := bySource[f.Name]
+		if !ok {
+			if f.Status != types.FileDeleted && matchesAny(in.TestGlobs, f.Name) {
+				resp.SelectAll = true
+				return resp
+			}
+			continue
+		}
+		for _, i := range idx {
+			selected[i] = true
+		}
+	}
+	for i, c := range chains {
+		if !selected[i] {
+			continue
+		}
+		t := c.Test
+		t.Selection = types.SelectSourceCode
+		resp.Tests = append(resp.Tests, t)
+	}
+	resp.SelectedTests = len(resp.Tests)
+	resp.SrcCodeTests = len(resp.Tests)
+	return resp
+}
+
+func matchesAny(globs []string, name string) bool {
+	for _, g := range globs {
+		if ok, _ := path.Match(g, name); ok {
+			return true
+		}
+	}
+	return false
+}
+
+// mergeChains replaces the chains of the tests present in update, keeping
+// the other ones, so that partial callgraphs of a split test run accumulate.
+func mergeChains(chains, update []Chain) []Chain {
+	replaced := make(map[string]bool, len(update))
+	for _, c := range update {
+		replaced[c.Test.CanonicalIdentity()] = true
+	}
+	out := make([]Chain, 0, len(chains)+len(update))
+	for _, c := range chains {
+		if !replaced[c.Test.CanonicalIdentity()] {
+			out = append(out, c)
+		}
+	}
+	return append(out, update...)
+}
+
+// PruneChains drops the sources of chains which no longer exist in the
+// workspace at root, and the chains left without any source, eg before
+// encoding a callgraph for UploadCg. Sources are slash separated paths
+// relative to root. chains is not modified.
+func PruneChains(chains []Chain, root string) []Chain {
+	exists := make(map[string]bool)
+	out := make([]Chain, 0, len(chains))
+	for _, c := range chains {
+		if len(c.Sources) == 0 {
+			out = append(out, c)
+			continue
+		}
+		var sources []string
+		for _, src := range c.Sources {
+			ok, seen := exists[src]
+			if !seen {
+				_, err := os.Stat(filepath.Join(root, filepath.FromSlash(src)))
+				ok = err == nil
+				exists[src] = ok
+			}
+			if ok {
+				sources = append(sources, src)
+			}
+		}
+		if len(sources) > 0 {
+			c.Sources = sources
+			out = append(out, c)
+		}
+	}
+	return out
+}
@@ -0,0 +1,76 @@
+package localclient
+
+import (
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+
+	"github.com/harness/ti-client/types"
+)
+
+const stateFile = "ti-local.json"
+
+// state is everything persisted by a local client.
+type state struct {
+	Chains      []Chain                      `json:"chains"`
+	Reports     map[string][]*types.TestCase `json:"reports"` // keyed by step, then test
+	MutingRules []types.MutingRule           `json:"muting_rules"`
+	NextRuleID  int                          `json:"next_rule_id"`
+}
+
+// load reads the state stored in dir. A missing store is empty.
+func load(dir string) (*state, error) {
+	s := &state{Reports: map[string][]*types.TestCase{}}
+	if err := readJSON(dir, stateFile, s); err != nil {
+		return nil, err
+	}
+	if s.Reports == nil {
+		s.Reports = map[string][]*types.TestCase{}
+	}
+	return s, nil
+}
+
+// save writes the state to dir.
+func (s *state) save(dir string) error {
+	return writeJSON(dir, stateFile, s)
+}
+
+// readJSON decodes the file name of dir into v, which is left unchanged if
+// the file doesn't exist.
+func readJSON(dir, name string, v interface{}) error {
+	b, err := os.ReadFile(filepath.Join(dir, name))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(b, v)
+}
+
+// writeJSON encodes v into the file name of dir through a temporary file,
+// so that a crash never leaves a partially written file behind.
+func writeJSON(dir, name string, v interface{}) error {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return err
+	}
+	tmp, err := os.CreateTemp(dir, name+".*.tmp")
+	if err != nil {
+		return err
+	}
+	if _, err := tmp.Write(b); err != nil {
+		tmp.Close()
+		os.Remove(tmp.Name())
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		os.Remove(tmp.Name())
+		return err
+	}
+	return os.Rename(tmp.Name(), filepath.Join(dir, name))
+}
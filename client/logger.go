@@ -0,0 +1,101 @@
+package client
+
+import (
+	"context"
+	"log"
+	"os"
+	"strconv"
+)
+
+// DebugEnv is the environment variable which enables request/response
+// debug logging when set to a true value.
+const DebugEnv = "HARNESS_TI_CLIENT_DEBUG"
+
+// maxLoggedBodySize is the number of body bytes included in debug logs.
+const maxLoggedBodySize = 1024
+
+// Logger is the logging interface used by the client. It is satisfied by
+// *logrus.Logger and *logrus.Entry.
+type Logger interface {
+	Debugf(format string, args ...interface{})
+	Infof(format string, args ...interface{})
+	Warnf(format string, args ...interface{})
+	Errorf(format string, args ...interface{})
+}
+
+// WithLogger sets the logger used by the client.
+func WithLogger(l Logger) Option {
+	return func(c *HTTPClient) {
+		c.log = l
+	}
+}
+
+// WithDebug enables request/response debug logging regardless of DebugEnv.
+func WithDebug(enabled bool) Option {
+	return func(c *HTTPClient) {
+		c.debug = enabled
+	}
+}
+
+// stdLogger is the Logger used when none is configured.
+type stdLogger struct {
+	l *log.Logger
+}
+
+var defaultLogger Logger = &stdLogger{l: log.New(os.Stderr, "ti-client: ", log.LstdFlags)}
+
+func (s *stdLogger) Debugf(format string, args ...interface{}) {
+	s.l.Printf("DEBUG "+format, args...)
+}
+
+func (s *stdLogger) Infof(format string, args ...interface{}) {
+	s.l.Printf("INFO "+format, args...)
+}
+
+func (s *stdLogger) Warnf(format string, args ...interface{}) {
+	s.l.Printf("WARN "+format, args...)
+}
+
+func (s *stdLogger) Errorf(format string, args ...interface{}) {
+	s.l.Printf("ERROR "+format, args...)
+}
+
+// logger returns the configured logger or the default one.
+func (c *HTTPClient) logger() Logger {
+	if c.log == nil {
+		return defaultLogger
+	}
+	return c.log
+}
+
+// debugEnabled reports whether request/response debug logging is on.
+func (c *HTTPClient) debugEnabled() bool {
+	if c.debug {
+		return true
+	}
+	enabled, _ := strconv.ParseBool(os.Getenv(DebugEnv))
+	return enabled
+}
+
+type attemptKey struct{}
+
+// withAttempt returns a copy of ctx recording the retry attempt number.
+func withAttempt(ctx context.Context, attempt int) context.Context {
+	return context.WithValue(ctx, attemptKey{}, attempt)
+}
+
+// attemptFrom returns the retry attempt number recorded in ctx, starting at 1.
+func attemptFrom(ctx context.Context) int {
+	if attempt, ok := ctx.Value(attemptKey{}).(int); ok {
+		return attempt
+	}
+	return 1
+}
+
+// truncateBody returns body as a string limited to maxLoggedBodySize bytes.
+func truncateBody(body []byte) string {
+	if len(body) <= maxLoggedBodySize {
+		return string(body)
+	}
+	return string(body[:maxLoggedBodySize]) + "...(truncated)"
+}
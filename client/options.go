@@ -0,0 +1,119 @@
+package client
+
+import (
+	"crypto"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/pem"
+	"fmt"
+	"os"
+
+	"github.com/harness/ti-client/types"
+)
+
+// defaultErrorBodyLimit is the default number of error body bytes captured.
+const defaultErrorBodyLimit = 64 * 1024
+
+// prHeader carries the pull request metadata set with WithPRMetadata.
+const prHeader = "X-Harness-PR"
+
+// Option configures optional behaviour of an HTTPClient.
+type Option func(*HTTPClient)
+
+// WithErrorBodyLimit sets the maximum number of bytes of an error response
+// body captured in the returned *Error. Defaults to 64KiB.
+func WithErrorBodyLimit(n int64) Option {
+	return func(c *HTTPClient) {
+		c.maxErrorBody = n
+	}
+}
+
+// WithPRMetadata attaches the pull request metadata of the build to the
+// requests of the client: it is sent with every request in the
+// X-Harness-PR header, as base64 encoded JSON, and fills the PR field of
+// selection requests which don't set it.
+func WithPRMetadata(pr types.PRMetadata) Option {
+	return func(c *HTTPClient) {
+		c.pr = &pr
+	}
+}
+
+// WithParentPipeline sets the parent pipeline execution of a child
+// pipeline, so that its selections and summaries are reported against the
+// parent context.
+func WithParentPipeline(pipelineID, buildID string) Option {
+	return func(c *HTTPClient) {
+		c.ParentPipelineID = pipelineID
+		c.ParentBuildID = buildID
+	}
+}
+
+// WithClientCertificate sets the mTLS client certificate used by the client.
+// It takes precedence over the certificate pair loaded from /etc/mtls.
+func WithClientCertificate(cert tls.Certificate) Option {
+	return func(c *HTTPClient) {
+		c.clientCert = &cert
+	}
+}
+
+// WithClientCertSigner sets the mTLS client certificate from a PEM encoded
+// certificate chain and a crypto.Signer holding the private key. The signer
+// can be backed by an OS keystore or a PKCS#11 token so that the private key
+// never has to be written to disk.
+func WithClientCertSigner(certPEM []byte, signer crypto.Signer) Option {
+	return func(c *HTTPClient) {
+		cert, err := signerCertificate(certPEM, signer)
+		if err != nil {
+			c.optErrs = append(c.optErrs, fmt.Errorf("failed to load mTLS cert with signer: %w", err))
+			return
+		}
+		c.clientCert = &cert
+	}
+}
+
+// LoadSignerCertificate reads a PEM encoded certificate chain from certFile
+// and pairs it with the private key held by signer.
+func LoadSignerCertificate(certFile string, signer crypto.Signer) (tls.Certificate, error) {
+	certPEM, err := os.ReadFile(certFile)
+	if err != nil {
+		return tls.Certificate{}, err
+	}
+	return signerCertificate(certPEM, signer)
+}
+
+// signerCertificate builds a tls.Certificate from a PEM encoded chain whose
+// private key operations are delegated to signer.
+func signerCertificate(certPEM []byte, signer crypto.Signer) (tls.Certificate, error) {
+	if signer == nil {
+		return tls.Certificate{}, fmt.Errorf("signer is not set")
+	}
+	var cert tls.Certificate
+	for {
+		var block *pem.Block
+		block, certPEM = pem.Decode(certPEM)
+		if block == nil {
+			break
+		}
+		if block.Type == "CERTIFICATE" {
+			cert.Certificate = append(cert.Certificate, block.Bytes)
+		}
+	}
+	if len(cert.Certificate) == 0 {
+		return tls.Certificate{}, fmt.Errorf("no certificate found in PEM data")
+	}
+	leaf, err := x509.ParseCertificate(cert.Certificate[0])
+	if err != nil {
+		return tls.Certificate{}, err
+	}
+	if !publicKeysEqual(leaf.PublicKey, signer.Public()) {
+		return tls.Certificate{}, fmt.Errorf("certificate public key does not match signer")
+	}
+	cert.Leaf = leaf
+	cert.PrivateKey = signer
+	return cert, nil
+}
+
+func publicKeysEqual(a, b crypto.PublicKey) bool {
+	k, ok := a.(interface{ Equal(crypto.PublicKey) bool })
+	return ok && k.Equal(b)
+}
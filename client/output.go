@@ -0,0 +1,123 @@
+package client
+
+import (
+	"unicode/utf8"
+
+	"github.com/harness/ti-client/types"
+)
+
+// OutputPolicy selects the test cases whose stdout/stderr Write uploads.
+type OutputPolicy string
+
+const (
+	// OutputAll uploads the output of every test case.
+	OutputAll OutputPolicy = ""
+	// OutputFailedOnly uploads the output of failed and errored test
+	// cases only. The output of passed tests is often most of the payload.
+	OutputFailedOnly OutputPolicy = "failed_only"
+	// OutputNever never uploads test output.
+	OutputNever OutputPolicy = "never"
+)
+
+// WithOutputCapture sets which test cases Write uploads stdout/stderr for
+// and limits the output uploaded per report to budget bytes, zero meaning
+// no limit. Failed tests get the budget first; output which doesn't fit
+// is truncated. The test cases passed to Write are not modified.
+func WithOutputCapture(policy OutputPolicy, budget int64) Option {
+	return func(c *HTTPClient) {
+		c.outputPolicy, c.outputBudget = policy, budget
+	}
+}
+
+// captureOutput applies the output policy and budget to tests, including
+// the invocations of aggregated parameterized tests, copying the test cases
+// whose output is dropped or truncated.
+func (c *HTTPClient) captureOutput(tests []*types.TestCase) []*types.TestCase {
+	if c.outputPolicy == OutputAll && c.outputBudget <= 0 {
+		return tests
+	}
+	out := make([]*types.TestCase, len(tests))
+	copy(out, tests)
+	remaining := c.outputBudget
+	capture := func(stdout, stderr string, failed bool) (string, string) {
+		if c.outputPolicy == OutputNever || (c.outputPolicy == OutputFailedOnly && !failed) {
+			stdout, stderr = "", ""
+		}
+		if c.outputBudget > 0 {
+			stdout = takeBudget(stdout, &remaining)
+			stderr = takeBudget(stderr, &remaining)
+		}
+		return stdout, stderr
+	}
+	// own returns out[i], copied along with its parameters on first use so
+	// that the test cases passed to Write are not modified
+	owned := make(map[int]bool)
+	own := func(i int) *types.TestCase {
+		if !owned[i] {
+			tc := *out[i]
+			tc.Parameters = append([]types.ParameterResult(nil), tc.Parameters...)
+			out[i], owned[i] = &tc, true
+		}
+		return out[i]
+	}
+	apply := func(failed bool) {
+		for i, t := range out {
+			if isFailed(t.Result) == failed && (t.SystemOut != "" || t.SystemErr != "") {
+				stdout, stderr := capture(t.SystemOut, t.SystemErr, failed)
+				if stdout != t.SystemOut || stderr != t.SystemErr {
+					t = own(i)
+					t.SystemOut, t.SystemErr = stdout, stderr
+				}
+			}
+			for j, p := range t.Parameters {
+				if isFailed(p.Result) != failed || (p.SystemOut == "" && p.SystemErr == "") {
+					continue
+				}
+				stdout, stderr := capture(p.SystemOut, p.SystemErr, failed)
+				if stdout != p.SystemOut || stderr != p.SystemErr {
+					t = own(i)
+					t.Parameters[j].SystemOut, t.Parameters[j].SystemErr = stdout, stderr
+				}
+			}
+		}
+	}
+	apply(true)
+	apply(false)
+	return out
+}
+
+func isFailed(r types.Result) bool {
+	return r.Status == types.StatusFailed || r.Status == types.StatusError
+}
+
+// takeBudget returns the prefix of s fitting in the remaining budget,
+// without splitting a UTF-8 sequence, and consumes it.
+func takeBudget(s string, remaining *int64) string {
+	if int64(len(s)) > *remaining {
+		n := int(*remaining)
+		for n > 0 && !utf8.RuneStart(s[n]) {
+			n--
+		}
+		s = s[:n]
+	}
+	*remaining -= int64(len(s))
+	return s
+}
+
+// WithResultSampling makes Write upload all the test cases which aren't
+// passed but only a sample of about rate (0-1) of the passed ones, with
+// counts preserved through types.TestCase.SampleWeight. It is meant for
+// suites with millions of test cases where full fidelity is unnecessary.
+func WithResultSampling(rate float64) Option {
+	return func(c *HTTPClient) {
+		c.sampleRate = rate
+	}
+}
+
+// sampleResults applies result sampling to tests, if enabled.
+func (c *HTTPClient) sampleResults(tests []*types.TestCase) []*types.TestCase {
+	if c.sampleRate <= 0 || c.sampleRate >= 1 {
+		return tests
+	}
+	return types.SampleResults(tests, c.sampleRate)
+}
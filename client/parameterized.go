@@ -0,0 +1,37 @@
+package client
+
+import "github.com/harness/ti-client/types"
+
+// ParameterizedMode selects the form in which Write uploads the invocations
+// of parameterized tests.
+type ParameterizedMode string
+
+const (
+	// ParameterizedAsGiven uploads the test cases as passed to Write.
+	ParameterizedAsGiven ParameterizedMode = ""
+	// ParameterizedFlattened uploads one test case per invocation.
+	ParameterizedFlattened ParameterizedMode = "flattened"
+	// ParameterizedAggregated uploads one test case per parameterized test
+	// with the results of its invocations, so that large parameter
+	// matrices don't swamp summaries.
+	ParameterizedAggregated ParameterizedMode = "aggregated"
+)
+
+// WithParameterizedTests sets the form in which Write uploads parameterized
+// tests, see types.AggregateParameterized.
+func WithParameterizedTests(mode ParameterizedMode) Option {
+	return func(c *HTTPClient) {
+		c.paramMode = mode
+	}
+}
+
+// shapeParameterized converts tests to the configured parameterized form.
+func (c *HTTPClient) shapeParameterized(tests []*types.TestCase) []*types.TestCase {
+	switch c.paramMode {
+	case ParameterizedFlattened:
+		return types.FlattenParameterized(tests)
+	case ParameterizedAggregated:
+		return types.AggregateParameterized(tests)
+	}
+	return tests
+}
@@ -0,0 +1,134 @@
+package client
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/harness/ti-client/types"
+)
+
+// ErrPayloadTooLarge is matched by errors.Is for *PayloadTooLargeError.
+var ErrPayloadTooLarge = errors.New("payload too large")
+
+// PayloadTooLargeError is returned when a request payload exceeds the
+// configured limit, before anything is uploaded.
+type PayloadTooLargeError struct {
+	Operation  string
+	Size       int64
+	Limit      int64
+	Suggestion string
+}
+
+func (e *PayloadTooLargeError) Error() string {
+	return fmt.Sprintf("%s payload of %d bytes exceeds the limit of %d bytes: %s", e.Operation, e.Size, e.Limit, e.Suggestion)
+}
+
+// Is reports whether target is ErrPayloadTooLarge.
+func (e *PayloadTooLargeError) Is(target error) bool {
+	return target == ErrPayloadTooLarge
+}
+
+// WithMaxWriteSize limits the encoded size of Write payloads to n bytes.
+func WithMaxWriteSize(n int64) Option {
+	return func(c *HTTPClient) {
+		c.maxWriteSize = n
+	}
+}
+
+// WithMaxUploadCgSize limits the encoded size of UploadCg payloads to n bytes.
+func WithMaxUploadCgSize(n int64) Option {
+	return func(c *HTTPClient) {
+		c.maxUploadCgSize = n
+	}
+}
+
+// checkPayloadSize returns a *PayloadTooLargeError if the JSON encoding of
+// in is larger than limit. A limit of zero disables the check.
+func checkPayloadSize(operation string, in interface{}, limit int64, suggestion string) error {
+	if limit <= 0 {
+		return nil
+	}
+	size, err := encodedSize(in)
+	if err != nil {
+		return err
+	}
+	if size > limit {
+		return &PayloadTooLargeError{Operation: operation, Size: size, Limit: limit, Suggestion: suggestion}
+	}
+	return nil
+}
+
+// encodedSize returns the size of the JSON encoding of v without
+// buffering it.
+func encodedSize(v interface{}) (int64, error) {
+	var w countingWriter
+	err := encodeJSON(&w, v)
+	return int64(w), err
+}
+
+// encodeJSON writes the JSON encoding of v to w, as json.Encoder does.
+// Callgraphs and test cases, the payloads which can be large, are streamed
+// rather than encoded in memory first.
+func encodeJSON(w io.Writer, v interface{}) error {
+	switch v := v.(type) {
+	case *[]byte:
+		if v != nil {
+			return encodeJSON(w, *v)
+		}
+	case []byte:
+		if v == nil {
+			break
+		}
+		if _, err := io.WriteString(w, `"`); err != nil {
+			return err
+		}
+		enc := base64.NewEncoder(base64.StdEncoding, w)
+		if _, err := enc.Write(v); err != nil {
+			return err
+		}
+		if err := enc.Close(); err != nil {
+			return err
+		}
+		_, err := io.WriteString(w, "\"\n")
+		return err
+	case *[]*types.TestCase:
+		if v != nil {
+			return encodeJSON(w, *v)
+		}
+	case []*types.TestCase:
+		if v == nil {
+			break
+		}
+		sep := "["
+		for _, t := range v {
+			data, err := json.Marshal(t)
+			if err != nil {
+				return err
+			}
+			if _, err := io.WriteString(w, sep); err != nil {
+				return err
+			}
+			if _, err := w.Write(data); err != nil {
+				return err
+			}
+			sep = ","
+		}
+		if sep == "[" {
+			_, err := io.WriteString(w, "[]\n")
+			return err
+		}
+		_, err := io.WriteString(w, "]\n")
+		return err
+	}
+	return json.NewEncoder(w).Encode(v)
+}
+
+type countingWriter int64
+
+func (w *countingWriter) Write(p []byte) (int, error) {
+	*w += countingWriter(len(p))
+	return len(p), nil
+}
@@ -0,0 +1,28 @@
+package client
+
+import (
+	"errors"
+	"fmt"
+)
+
+// ErrReadOnly is returned by the methods which would modify TI data when
+// the client is read-only.
+var ErrReadOnly = errors.New("ti client is read-only")
+
+// WithReadOnly makes every method modifying TI data (writing reports,
+// savings, callgraphs, muting rules, webhooks, ...) a no-op returning
+// ErrReadOnly, for preview executions and debugging sessions which must
+// not pollute TI data. Queries, including test selection, still work.
+func WithReadOnly() Option {
+	return func(c *HTTPClient) {
+		c.readOnly = true
+	}
+}
+
+// checkWritable returns ErrReadOnly if the client is read-only.
+func (c *HTTPClient) checkWritable(operation string) error {
+	if c.readOnly {
+		return fmt.Errorf("%s: %w", operation, ErrReadOnly)
+	}
+	return nil
+}
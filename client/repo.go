@@ -0,0 +1,33 @@
+package client
+
+import "context"
+
+type repoKey struct{}
+
+type repoOverride struct {
+	repo, sha string
+}
+
+// WithRepo returns a copy of ctx attributing the calls made with it to the
+// given repository and commit instead of the Repo and Sha of the client, for
+// stages which check out several repositories. An empty sha keeps the
+// commit of the client.
+func WithRepo(ctx context.Context, repo, sha string) context.Context {
+	return context.WithValue(ctx, repoKey{}, repoOverride{repo: repo, sha: sha})
+}
+
+// repo returns the repository of the calls made with ctx.
+func (c *HTTPClient) repo(ctx context.Context) string {
+	if o, ok := ctx.Value(repoKey{}).(repoOverride); ok && o.repo != "" {
+		return o.repo
+	}
+	return c.Repo
+}
+
+// sha returns the commit of the calls made with ctx.
+func (c *HTTPClient) sha(ctx context.Context) string {
+	if o, ok := ctx.Value(repoKey{}).(repoOverride); ok && o.sha != "" {
+		return o.sha
+	}
+	return c.Sha
+}
@@ -0,0 +1,96 @@
+package client
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"reflect"
+)
+
+// schemaVersionHeader carries the schema version of TI response bodies.
+const schemaVersionHeader = "X-Harness-TI-Schema-Version"
+
+// ResponseAdapter rewrites a response body of an older schema into the
+// shape decoded by the current client.
+type ResponseAdapter func(body []byte) ([]byte, error)
+
+type adapterKey struct {
+	typ     reflect.Type
+	version string
+}
+
+// WithResponseAdapter registers adapt to rewrite the response bodies
+// decoded into values of the type of out, e.g. &types.SelectTestsResp{},
+// when the server reports the given schema version. It lets the client
+// talk to the current and previous TI server releases during rollouts.
+func WithResponseAdapter(out interface{}, version string, adapt ResponseAdapter) Option {
+	return func(c *HTTPClient) {
+		if c.adapters == nil {
+			c.adapters = map[adapterKey]ResponseAdapter{}
+		}
+		c.adapters[adapterKey{typ: valueType(out), version: version}] = adapt
+	}
+}
+
+// ServerSchemaVersion returns the schema version reported by the server
+// on the last response carrying one, or "" if none did.
+func (c *HTTPClient) ServerSchemaVersion() string {
+	v, _ := c.schemaVersion.Load().(string)
+	return v
+}
+
+// RenameFields returns an adapter renaming the JSON fields of an object,
+// or of each object of an array, from their old to their new name.
+func RenameFields(renames map[string]string) ResponseAdapter {
+	rename := func(obj map[string]json.RawMessage) {
+		for old, name := range renames {
+			if v, ok := obj[old]; ok {
+				if _, exists := obj[name]; !exists {
+					obj[name] = v
+				}
+				delete(obj, old)
+			}
+		}
+	}
+	return func(body []byte) ([]byte, error) {
+		var obj map[string]json.RawMessage
+		if err := json.Unmarshal(body, &obj); err == nil {
+			rename(obj)
+			return json.Marshal(obj)
+		}
+		var objs []map[string]json.RawMessage
+		if err := json.Unmarshal(body, &objs); err != nil {
+			return nil, fmt.Errorf("response is neither an object nor an array of objects: %w", err)
+		}
+		for _, obj := range objs {
+			rename(obj)
+		}
+		return json.Marshal(objs)
+	}
+}
+
+// decode unmarshals body into out, adapting it first if it has an older
+// schema for which an adapter is registered.
+func (c *HTTPClient) decode(res *http.Response, body []byte, out interface{}) error {
+	version := res.Header.Get(schemaVersionHeader)
+	if version != "" {
+		c.schemaVersion.Store(version)
+		if adapt, ok := c.adapters[adapterKey{typ: valueType(out), version: version}]; ok {
+			adapted, err := adapt(body)
+			if err != nil {
+				return fmt.Errorf("adapting response of schema version %s: %w", version, err)
+			}
+			body = adapted
+		}
+	}
+	return json.Unmarshal(body, out)
+}
+
+// valueType returns the type of the value v points to, or of v itself.
+func valueType(v interface{}) reflect.Type {
+	t := reflect.TypeOf(v)
+	if t != nil && t.Kind() == reflect.Pointer {
+		return t.Elem()
+	}
+	return t
+}
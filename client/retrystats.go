@@ -0,0 +1,66 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// RetryStats describes how a call went through the retry loop.
+type RetryStats struct {
+	Method string
+	URL    string // sanitized
+	// Attempts is the number of attempts made, including the first one.
+	Attempts int
+	// Backoff is the total time spent waiting between attempts.
+	Backoff time.Duration
+	// Elapsed is the total duration of the call.
+	Elapsed time.Duration
+	// StatusCode is the status of the final response, or 0 if none.
+	StatusCode int
+	Err        error
+}
+
+// Retries returns the number of attempts after the first one.
+func (s RetryStats) Retries() int {
+	if s.Attempts == 0 {
+		return 0
+	}
+	return s.Attempts - 1
+}
+
+func (s RetryStats) String() string {
+	outcome := "succeeded"
+	if s.Err != nil {
+		outcome = "failed"
+	}
+	return fmt.Sprintf("%s %s %s after %d retries over %s (status %d)",
+		s.Method, s.URL, outcome, s.Retries(), s.Elapsed.Round(time.Second), s.StatusCode)
+}
+
+type retryStatsKey struct{}
+
+// WithRetryStats returns a copy of ctx which makes calls record their
+// retry statistics into stats.
+func WithRetryStats(ctx context.Context, stats *RetryStats) context.Context {
+	return context.WithValue(ctx, retryStatsKey{}, stats)
+}
+
+// WithRetryObserver registers a callback receiving the retry statistics of
+// every call made through the retry loop.
+func WithRetryObserver(fn func(ctx context.Context, stats RetryStats)) Option {
+	return func(c *HTTPClient) {
+		c.retryObserver = fn
+	}
+}
+
+// reportRetryStats hands the statistics of a call to the context and the
+// client observer.
+func (c *HTTPClient) reportRetryStats(ctx context.Context, stats *RetryStats) {
+	if dst, ok := ctx.Value(retryStatsKey{}).(*RetryStats); ok && dst != nil {
+		*dst = *stats
+	}
+	if c.retryObserver != nil {
+		c.retryObserver(ctx, *stats)
+	}
+}
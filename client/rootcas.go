@@ -0,0 +1,194 @@
+package client
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// WithRootCAReload makes the client reload the additional certs directory
+// when its files change, checking at most once per interval, so that root
+// CA rotation doesn't require a process restart.
+func WithRootCAReload(interval time.Duration) Option {
+	return func(c *HTTPClient) {
+		c.rootCAReload = interval
+	}
+}
+
+// isCertFile reports whether a file of the additional certs directory is
+// expected to contain certificates.
+func isCertFile(name string) bool {
+	switch strings.ToLower(filepath.Ext(name)) {
+	case ".pem", ".crt":
+		return true
+	}
+	return false
+}
+
+// loadRootCAs loads custom root CAs from the *.pem and *.crt files of the
+// provided directory, on top of the system pool. In strict mode a directory
+// or files which can't be loaded are errors, otherwise they are logged and
+// skipped, and nil is returned if the directory can't be read.
+func loadRootCAs(dir string, strict bool, log Logger) (*x509.CertPool, error) {
+	if dir == "" {
+		return nil, nil
+	}
+
+	rootCAs, _ := x509.SystemCertPool()
+	if rootCAs == nil {
+		rootCAs = x509.NewCertPool()
+	}
+
+	files, err := os.ReadDir(dir)
+	if err != nil {
+		if strict {
+			return nil, fmt.Errorf("could not read additional certs directory: %w", err)
+		}
+		log.Warnf("could not read additional certs directory (%s), error: %s", dir, err)
+		return nil, nil
+	}
+
+	// Go through all certs in this directory and add them to the global certs
+	for _, f := range files {
+		if f.IsDir() || !isCertFile(f.Name()) {
+			continue
+		}
+		path := filepath.Join(dir, f.Name())
+		rootPem, err := os.ReadFile(path)
+		if err != nil {
+			if strict {
+				return nil, fmt.Errorf("could not read certificate file: %w", err)
+			}
+			log.Warnf("could not read certificate file (%s), error: %s", path, err)
+			continue
+		}
+		// Append certs to the global certs
+		if ok := rootCAs.AppendCertsFromPEM(rootPem); !ok {
+			if strict {
+				return nil, fmt.Errorf("could not add cert %s to pool, please check format of the certs provided", path)
+			}
+			log.Warnf("error adding cert (%s) to pool, please check format of the certs provided", path)
+			continue
+		}
+		log.Debugf("added cert at %s to root certs", path)
+	}
+	return rootCAs, nil
+}
+
+// certDirSignature summarizes the names, sizes and modification times of
+// the certificate files of dir, to detect changes cheaply. Symlinks are
+// followed, as Kubernetes secret and config map mounts rotate files by
+// swapping the directory their symlinks point to.
+func certDirSignature(dir string) (string, error) {
+	files, err := os.ReadDir(dir)
+	if err != nil {
+		return "", err
+	}
+	var entries []string
+	for _, f := range files {
+		if f.IsDir() || !isCertFile(f.Name()) {
+			continue
+		}
+		info, err := os.Stat(filepath.Join(dir, f.Name()))
+		if err != nil {
+			return "", err
+		}
+		if info.IsDir() {
+			continue
+		}
+		entries = append(entries, fmt.Sprintf("%s:%d:%d", f.Name(), info.Size(), info.ModTime().UnixNano()))
+	}
+	sort.Strings(entries)
+	return strings.Join(entries, "\n"), nil
+}
+
+// reloadingRootCAs holds the root CA pool of a client, reloaded when the
+// additional certs directory changes.
+type reloadingRootCAs struct {
+	dir      string
+	interval time.Duration
+	log      Logger
+
+	mu      sync.Mutex
+	pool    *x509.CertPool
+	sig     string
+	checked time.Time
+}
+
+// reloadRootCAs switches the TLS configuration of hc to verify servers
+// against a root CA pool reloaded from the additional certs directory.
+func (c *HTTPClient) reloadRootCAs(hc *http.Client, pool *x509.CertPool) {
+	t, ok := hc.Transport.(*http.Transport)
+	if !ok || t.TLSClientConfig == nil {
+		return
+	}
+	sig, _ := certDirSignature(c.certsDir)
+	r := &reloadingRootCAs{
+		dir:      c.certsDir,
+		interval: c.rootCAReload,
+		log:      c.logger(),
+		pool:     pool,
+		sig:      sig,
+		checked:  time.Now(),
+	}
+	// tls.Config.RootCAs can't be swapped once the transport is in use, so
+	// the built-in verification is replaced by one reading the current pool
+	t.TLSClientConfig.InsecureSkipVerify = true //nolint:gosec
+	t.TLSClientConfig.VerifyConnection = r.verify
+}
+
+// current returns the root CA pool, reloading it first if the directory
+// changed since it was last checked.
+func (r *reloadingRootCAs) current() *x509.CertPool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if time.Since(r.checked) < r.interval {
+		return r.pool
+	}
+	r.checked = time.Now()
+	sig, err := certDirSignature(r.dir)
+	if err != nil {
+		r.log.Warnf("could not check additional certs directory %s, error: %s", r.dir, err)
+		return r.pool
+	}
+	if sig == r.sig {
+		return r.pool
+	}
+	pool, err := loadRootCAs(r.dir, false, r.log)
+	if err != nil {
+		r.log.Warnf("could not reload root certs, error: %s", err)
+		return r.pool
+	}
+	if pool == nil {
+		// the directory could not be read, which was logged
+		return r.pool
+	}
+	r.pool, r.sig = pool, sig
+	r.log.Infof("reloaded root certs from %s", r.dir)
+	return r.pool
+}
+
+// verify performs the server certificate verification done by crypto/tls
+// against the current root CA pool.
+func (r *reloadingRootCAs) verify(cs tls.ConnectionState) error {
+	if len(cs.PeerCertificates) == 0 {
+		return fmt.Errorf("server presented no certificate")
+	}
+	opts := x509.VerifyOptions{
+		DNSName:       cs.ServerName,
+		Roots:         r.current(),
+		Intermediates: x509.NewCertPool(),
+	}
+	for _, cert := range cs.PeerCertificates[1:] {
+		opts.Intermediates.AddCert(cert)
+	}
+	_, err := cs.PeerCertificates[0].Verify(opts)
+	return err
+}
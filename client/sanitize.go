@@ -0,0 +1,63 @@
+package client
+
+import (
+	"errors"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// sensitiveParams are query parameters whose values are never logged,
+// traced, audited or included in errors. They cover tokens, API keys and
+// the signatures of pre-signed download URLs.
+var sensitiveParams = []string{
+	"token", "x-harness-token", "access_token", "api_key", "apikey",
+	"signature", "sig", "x-amz-signature", "x-amz-credential", "x-amz-security-token",
+	"x-goog-signature", "x-goog-credential",
+}
+
+// sensitiveHeaders are headers which may carry credentials.
+var sensitiveHeaders = []string{"X-Harness-Token", "X-Api-Key", "Authorization", "Proxy-Authorization", "Set-Cookie", "Cookie"}
+
+// SanitizeURL returns rawURL without user info and with the values of
+// sensitive query parameters replaced, so that it can be safely logged.
+// Every URL which appears in errors, logs, traces or audit records goes
+// through it. A URL which can't be parsed, and so can't be sanitized, is
+// replaced as a whole.
+func SanitizeURL(rawURL string) string {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return "REDACTED"
+	}
+	u.User = nil
+	q := u.Query()
+	for k := range q {
+		for _, p := range sensitiveParams {
+			if strings.EqualFold(k, p) {
+				q.Set(k, "REDACTED")
+			}
+		}
+	}
+	u.RawQuery = q.Encode()
+	return u.String()
+}
+
+// SanitizeHeader returns a copy of h without the headers which may carry
+// credentials.
+func SanitizeHeader(h http.Header) http.Header {
+	clean := h.Clone()
+	for _, k := range sensitiveHeaders {
+		clean.Del(k)
+	}
+	return clean
+}
+
+// sanitizeError sanitizes the URL embedded in transport errors, which
+// net/http reports verbatim.
+func sanitizeError(err error) error {
+	var uerr *url.Error
+	if errors.As(err, &uerr) {
+		uerr.URL = SanitizeURL(uerr.URL)
+	}
+	return err
+}
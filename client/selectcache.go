@@ -0,0 +1,64 @@
+package client
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/harness/ti-client/types"
+)
+
+// WithSelectionCache caches SelectTests responses as files in dir for ttl,
+// keyed by repo, sha, source and target branch and a digest of the request.
+// Re-runs of the same commit then get identical results without a round trip.
+func WithSelectionCache(dir string, ttl time.Duration) Option {
+	return func(c *HTTPClient) {
+		c.selectCache = &selectionCache{dir: dir, ttl: ttl}
+	}
+}
+
+// selectionCache is an on-disk cache of selection responses.
+type selectionCache struct {
+	dir string
+	ttl time.Duration
+}
+
+type selectionCacheEntry struct {
+	ExpiresAt time.Time             `json:"expires_at"`
+	Resp      types.SelectTestsResp `json:"resp"`
+}
+
+// key returns the cache key of a selection request.
+func (s *selectionCache) key(repo, sha, source, target string, in *types.SelectTestsReq) string {
+	req, _ := json.Marshal(in)
+	h := sha256.New()
+	for _, part := range []string{repo, sha, source, target} {
+		h.Write([]byte(part))
+		h.Write([]byte{0})
+	}
+	h.Write(req)
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+func (s *selectionCache) get(key string) (types.SelectTestsResp, bool) {
+	var entry selectionCacheEntry
+	data, err := os.ReadFile(filepath.Join(s.dir, key+".json"))
+	if err != nil {
+		return entry.Resp, false
+	}
+	if err := json.Unmarshal(data, &entry); err != nil || time.Now().After(entry.ExpiresAt) {
+		return types.SelectTestsResp{}, false
+	}
+	return entry.Resp, true
+}
+
+func (s *selectionCache) put(key string, resp types.SelectTestsResp) error {
+	data, err := json.Marshal(selectionCacheEntry{ExpiresAt: time.Now().Add(s.ttl), Resp: resp})
+	if err != nil {
+		return err
+	}
+	return writeFileAtomic(s.dir, key+".json", data)
+}
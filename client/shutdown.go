@@ -0,0 +1,112 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"sync"
+)
+
+// Close stops accepting new calls, which fail with ErrClientClosed, and
+// waits for the calls in flight, including their retries, to complete or
+// for ctx to be done. Calls still in flight when ctx is done stop retrying.
+// Close then closes the idle pooled connections of the transport created by
+// the client, if any; an http.Client provided by the caller and the shared
+// http.DefaultTransport are left alone. Daemons embedding the client
+// should call it on shutdown.
+func (c *HTTPClient) Close(ctx context.Context) error {
+	idle := c.life.close()
+	var err error
+	select {
+	case <-idle:
+	case <-ctx.Done():
+		c.life.abort()
+		err = fmt.Errorf("ti client closed with calls in flight: %w", ctx.Err())
+	}
+	c.initMu.Lock()
+	if c.ownTransport != nil {
+		c.ownTransport.CloseIdleConnections()
+	}
+	c.initMu.Unlock()
+	return err
+}
+
+type admittedKey struct{}
+
+// lifecycle tracks the calls in flight so that Close can drain them. The
+// zero value accepts calls.
+type lifecycle struct {
+	mu       sync.Mutex
+	closed   bool
+	inflight int
+	idle     chan struct{}
+	aborted  chan struct{}
+}
+
+// begin admits a call unless the client is closed. Calls nested in an
+// admitted call, such as the attempts of a retried call, are always
+// admitted. end must be called once the call completed.
+func (c *HTTPClient) begin(ctx context.Context) (_ context.Context, end func(), err error) {
+	if ctx.Value(admittedKey{}) != nil {
+		return ctx, func() {}, nil
+	}
+	l := &c.life
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.closed {
+		return ctx, nil, ErrClientClosed
+	}
+	l.inflight++
+	return context.WithValue(ctx, admittedKey{}, true), l.end, nil
+}
+
+func (l *lifecycle) end() {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.inflight--
+	if l.closed && l.inflight == 0 && l.idle != nil {
+		close(l.idle)
+		l.idle = nil
+	}
+}
+
+// close stops admitting calls and returns a channel closed once no call
+// is in flight.
+func (l *lifecycle) close() <-chan struct{} {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.closed = true
+	if l.inflight == 0 {
+		idle := make(chan struct{})
+		close(idle)
+		return idle
+	}
+	if l.idle == nil {
+		l.idle = make(chan struct{})
+	}
+	return l.idle
+}
+
+// abort makes the calls in flight stop retrying.
+func (l *lifecycle) abort() {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.aborted == nil {
+		l.aborted = make(chan struct{})
+	}
+	select {
+	case <-l.aborted:
+	default:
+		close(l.aborted)
+	}
+}
+
+// abortedc returns a channel closed once Close gave up waiting for the
+// calls in flight.
+func (l *lifecycle) abortedc() <-chan struct{} {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.aborted == nil {
+		l.aborted = make(chan struct{})
+	}
+	return l.aborted
+}
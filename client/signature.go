@@ -0,0 +1,168 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+
+	"github.com/harness/ti-client/types"
+)
+
+// maxSignatureSize caps the size of a downloaded signature file.
+const maxSignatureSize = 64 * 1024
+
+// ErrUnsigned is returned when agent signing keys are configured but a
+// download link does not reference a signature.
+var ErrUnsigned = errors.New("agent artifact is not signed")
+
+// WithAgentSigningKey registers a PEM encoded public key under id. Once at
+// least one key is registered DownloadAgent refuses artifacts that do not
+// carry a valid signature from a registered key. Signatures are in the
+// format produced by `cosign sign-blob --key`: a base64 encoded signature
+// over the SHA-256 digest of the artifact (ECDSA, RSA PKCS#1 v1.5) or over
+// the artifact itself (Ed25519). A key which fails to load makes
+// DownloadAgent refuse all artifacts rather than skip verification.
+func WithAgentSigningKey(id string, pemKey []byte) Option {
+	return func(c *HTTPClient) {
+		key, err := parsePublicKey(pemKey)
+		if err != nil {
+			err = fmt.Errorf("failed to load agent signing key %s: %w", id, err)
+			c.optErrs = append(c.optErrs, err)
+			c.agentKeyErrs = append(c.agentKeyErrs, err)
+			return
+		}
+		if c.agentKeys == nil {
+			c.agentKeys = make(map[string]crypto.PublicKey)
+		}
+		c.agentKeys[id] = key
+	}
+}
+
+func parsePublicKey(pemKey []byte) (crypto.PublicKey, error) {
+	block, _ := pem.Decode(pemKey)
+	if block == nil {
+		return nil, fmt.Errorf("no public key found in PEM data")
+	}
+	key, err := x509.ParsePKIXPublicKey(block.Bytes)
+	if err != nil {
+		return nil, err
+	}
+	switch key.(type) {
+	case *ecdsa.PublicKey, *rsa.PublicKey, ed25519.PublicKey:
+		return key, nil
+	}
+	return nil, fmt.Errorf("unsupported public key type %T", key)
+}
+
+// VerifyAgent checks the signature referenced by link against the artifact
+// stored at path.
+func (c *HTTPClient) VerifyAgent(ctx context.Context, link types.DownloadLink, path string) error {
+	ctx, end, err := c.begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer end()
+	f, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	return c.verifyAgent(ctx, link, f)
+}
+
+func (c *HTTPClient) verifyAgent(ctx context.Context, link types.DownloadLink, f *os.File) error {
+	if link.SignatureURL == "" {
+		return fmt.Errorf("%s: %w", link.RelPath, ErrUnsigned)
+	}
+	var keys map[string]crypto.PublicKey
+	if link.PublicKeyID != "" {
+		key, ok := c.agentKeys[link.PublicKeyID]
+		if !ok {
+			return fmt.Errorf("%s: unknown signing key %q", link.RelPath, link.PublicKeyID)
+		}
+		keys = map[string]crypto.PublicKey{link.PublicKeyID: key}
+	} else {
+		keys = c.agentKeys
+	}
+	if len(keys) == 0 {
+		return fmt.Errorf("%s: no agent signing keys configured", link.RelPath)
+	}
+
+	sig, err := c.fetchSignature(ctx, link.SignatureURL)
+	if err != nil {
+		return err
+	}
+	if _, err := f.Seek(0, io.SeekStart); err != nil {
+		return err
+	}
+	// Ed25519 signs the message itself rather than a digest, so the
+	// artifact is only held in memory when such a key may be used
+	h := sha256.New()
+	var w io.Writer = h
+	var buf bytes.Buffer
+	for _, key := range keys {
+		if _, ok := key.(ed25519.PublicKey); ok {
+			w = io.MultiWriter(h, &buf)
+			break
+		}
+	}
+	if _, err := io.Copy(w, f); err != nil {
+		return err
+	}
+	data, digest := buf.Bytes(), h.Sum(nil)
+	for _, key := range keys {
+		if verifySignature(key, data, digest, sig) {
+			return nil
+		}
+	}
+	return fmt.Errorf("%s: signature verification failed", link.RelPath)
+}
+
+func (c *HTTPClient) fetchSignature(ctx context.Context, url string) ([]byte, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+	hc, err := c.httpClient()
+	if err != nil {
+		return nil, err
+	}
+	res, err := hc.Do(req)
+	if err != nil {
+		return nil, sanitizeError(err)
+	}
+	defer res.Body.Close()
+	if res.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("downloading signature %s: unexpected status %s", SanitizeURL(url), res.Status)
+	}
+	b, err := io.ReadAll(io.LimitReader(res.Body, maxSignatureSize))
+	if err != nil {
+		return nil, err
+	}
+	return base64.StdEncoding.DecodeString(strings.TrimSpace(string(b)))
+}
+
+func verifySignature(key crypto.PublicKey, data, digest, sig []byte) bool {
+	switch k := key.(type) {
+	case *ecdsa.PublicKey:
+		return ecdsa.VerifyASN1(k, digest, sig)
+	case *rsa.PublicKey:
+		return rsa.VerifyPKCS1v15(k, crypto.SHA256, digest, sig) == nil
+	case ed25519.PublicKey:
+		return ed25519.Verify(k, data, sig)
+	}
+	return false
+}
@@ -0,0 +1,119 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/harness/ti-client/types"
+)
+
+// WithWriteSplitting makes Write split payloads larger than the limit set
+// by WithMaxWriteSize into several sequential requests instead of failing.
+func WithWriteSplitting() Option {
+	return func(c *HTTPClient) {
+		c.splitWrites = true
+	}
+}
+
+// BatchResult is the outcome of writing one batch of a split write.
+type BatchResult struct {
+	// Index is the position of the batch, starting at zero.
+	Index int
+	Tests []*types.TestCase
+	Err   error
+}
+
+// PartialWriteError is returned by a split Write when some batches failed.
+// It lists the outcome of every batch so that callers can retry only the
+// failed tests.
+type PartialWriteError struct {
+	Batches []BatchResult
+}
+
+func (e *PartialWriteError) Error() string {
+	var failed, tests int
+	for _, b := range e.Batches {
+		if b.Err != nil {
+			failed++
+			tests += len(b.Tests)
+		}
+	}
+	return fmt.Sprintf("%d/%d write batches (%d tests) failed: %v", failed, len(e.Batches), tests, errors.Join(e.Unwrap()...))
+}
+
+// Unwrap returns the errors of the failed batches.
+func (e *PartialWriteError) Unwrap() []error {
+	var errs []error
+	for _, b := range e.Batches {
+		if b.Err != nil {
+			errs = append(errs, fmt.Errorf("batch %d/%d (%d tests): %w", b.Index+1, len(e.Batches), len(b.Tests), b.Err))
+		}
+	}
+	return errs
+}
+
+// Failed returns the tests of the failed batches, in order.
+func (e *PartialWriteError) Failed() []*types.TestCase {
+	var tests []*types.TestCase
+	for _, b := range e.Batches {
+		if b.Err != nil {
+			tests = append(tests, b.Tests...)
+		}
+	}
+	return tests
+}
+
+// writeSplit writes tests in order in batches which fit within the write
+// size limit. All batches are attempted; if any fails, a
+// *PartialWriteError reports the outcome of each.
+func (c *HTTPClient) writeSplit(ctx context.Context, stepID, report string, tests []*types.TestCase) error {
+	batches, err := splitTests(tests, c.maxWriteSize)
+	if err != nil {
+		return err
+	}
+	results := make([]BatchResult, len(batches))
+	failed := false
+	for i, batch := range batches {
+		results[i] = BatchResult{Index: i, Tests: batch, Err: c.write(ctx, stepID, report, batch)}
+		failed = failed || results[i].Err != nil
+	}
+	if failed {
+		return &PartialWriteError{Batches: results}
+	}
+	return nil
+}
+
+// splitTests splits tests, preserving order, into batches whose JSON
+// encoding is at most limit bytes.
+func splitTests(tests []*types.TestCase, limit int64) ([][]*types.TestCase, error) {
+	const arrayOverhead = 3 // brackets and trailing newline
+	var batches [][]*types.TestCase
+	var batch []*types.TestCase
+	size := int64(arrayOverhead)
+	for _, t := range tests {
+		n, err := encodedSize(t)
+		if err != nil {
+			return nil, err
+		}
+		// the encoder appends a newline which stands in for the comma
+		if n+arrayOverhead > limit {
+			return nil, &PayloadTooLargeError{
+				Operation:  "write",
+				Size:       n + arrayOverhead,
+				Limit:      limit,
+				Suggestion: fmt.Sprintf("test %q alone exceeds the limit, reduce its captured stdout/stderr", t.Name),
+			}
+		}
+		if len(batch) > 0 && size+n > limit {
+			batches = append(batches, batch)
+			batch, size = nil, arrayOverhead
+		}
+		batch = append(batch, t)
+		size += n
+	}
+	if len(batch) > 0 {
+		batches = append(batches, batch)
+	}
+	return batches, nil
+}
@@ -0,0 +1,122 @@
+package client
+
+import (
+	"compress/gzip"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"net/http"
+	"os"
+)
+
+// uploadStaging configures the staging of upload bodies on disk.
+type uploadStaging struct {
+	dir      string
+	minSize  int64
+	compress bool
+}
+
+// WithUploadStaging makes Write and UploadCg stream the encoding of their
+// payload to a temporary file in dir and send the request body from it,
+// instead of holding the encoded payload in memory. The payload is staged
+// once and the file is reused by all the attempts of the call. Only
+// payloads of at least minSize encoded bytes are staged; zero stages every
+// upload. If compress is set, the staged body is gzip compressed and sent
+// with Content-Encoding: gzip. An empty dir uses the default temp
+// directory.
+func WithUploadStaging(dir string, minSize int64, compress bool) Option {
+	return func(c *HTTPClient) {
+		c.staging = &uploadStaging{dir: dir, minSize: minSize, compress: compress}
+	}
+}
+
+// stagedBody is a request body staged in a temporary file.
+type stagedBody struct {
+	path       string
+	size       int64
+	digest     string
+	compressed bool
+}
+
+type stagedBodyKey struct{}
+
+// withStagedBody returns a copy of ctx carrying body, to be sent by all the
+// attempts of a call.
+func withStagedBody(ctx context.Context, body *stagedBody) context.Context {
+	return context.WithValue(ctx, stagedBodyKey{}, body)
+}
+
+// stagedBodyFrom returns the staged body carried by ctx, if any.
+func stagedBodyFrom(ctx context.Context) *stagedBody {
+	body, _ := ctx.Value(stagedBodyKey{}).(*stagedBody)
+	return body
+}
+
+// stage encodes the body of upload requests to a temporary file. It
+// returns nil if staging is disabled, ctx is not an upload or the payload
+// is below the staging threshold.
+func (c *HTTPClient) stage(ctx context.Context, in interface{}) (*stagedBody, error) {
+	if c.staging == nil || in == nil || !isUpload(ctx) {
+		return nil, nil
+	}
+	f, err := os.CreateTemp(c.staging.dir, "ti-upload-*.json")
+	if err != nil {
+		return nil, err
+	}
+	body := &stagedBody{path: f.Name(), compressed: c.staging.compress}
+	encoded, err := body.encode(f, in)
+	if cerr := f.Close(); err == nil {
+		err = cerr
+	}
+	if err != nil || encoded < c.staging.minSize {
+		body.remove()
+		return nil, err
+	}
+	return body, nil
+}
+
+// encode streams the JSON encoding of in to f, computing the size and
+// digest of the bytes written, and returns the size of the encoding before
+// compression.
+func (b *stagedBody) encode(f *os.File, in interface{}) (int64, error) {
+	h := sha256.New()
+	var written, encoded countingWriter
+	var dst io.Writer = io.MultiWriter(f, h, &written)
+	var zw *gzip.Writer
+	if b.compressed {
+		zw = gzip.NewWriter(dst)
+		dst = zw
+	}
+	if err := encodeJSON(io.MultiWriter(dst, &encoded), in); err != nil {
+		return 0, err
+	}
+	if zw != nil {
+		if err := zw.Close(); err != nil {
+			return 0, err
+		}
+	}
+	b.size, b.digest = int64(written), hex.EncodeToString(h.Sum(nil))
+	return int64(encoded), nil
+}
+
+// open returns a reader of the staged file, to be closed by the caller.
+func (b *stagedBody) open() (io.ReadCloser, error) {
+	return os.Open(b.path)
+}
+
+// prepare sets the length, encoding and digest headers of req, and lets
+// the transport reopen the staged file if the body must be resent.
+func (b *stagedBody) prepare(req *http.Request) {
+	req.ContentLength = b.size
+	req.GetBody = b.open
+	if b.compressed {
+		req.Header.Set("Content-Encoding", "gzip")
+	}
+	req.Header.Set(bodyDigestHeader, b.digest)
+}
+
+// remove deletes the staged file.
+func (b *stagedBody) remove() {
+	os.Remove(b.path)
+}
@@ -0,0 +1,59 @@
+package client
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/harness/ti-client/types"
+)
+
+// TestTimesSnapshot is test timing data saved for offline splitting, e.g.
+// in builds of forks which have no access to the TI service.
+type TestTimesSnapshot struct {
+	CreatedAt time.Time              `json:"created_at"`
+	Times     types.GetTestTimesResp `json:"times"`
+}
+
+// Age returns how old the timing data is.
+func (s TestTimesSnapshot) Age() time.Duration {
+	return time.Since(s.CreatedAt)
+}
+
+// ExportTestTimes saves the timing data to a gzip compressed JSON file at
+// path, replacing it atomically.
+func ExportTestTimes(path string, times types.GetTestTimesResp) error {
+	var buf bytes.Buffer
+	zw := gzip.NewWriter(&buf)
+	snapshot := TestTimesSnapshot{CreatedAt: time.Now().UTC(), Times: times}
+	if err := json.NewEncoder(zw).Encode(snapshot); err != nil {
+		return err
+	}
+	if err := zw.Close(); err != nil {
+		return err
+	}
+	return writeFileAtomic(filepath.Dir(path), filepath.Base(path), buf.Bytes())
+}
+
+// ImportTestTimes loads timing data saved by ExportTestTimes. Callers
+// should check the Age of the snapshot to decide whether it is too stale.
+func ImportTestTimes(path string) (TestTimesSnapshot, error) {
+	var snapshot TestTimesSnapshot
+	f, err := os.Open(path)
+	if err != nil {
+		return snapshot, err
+	}
+	defer f.Close()
+	zr, err := gzip.NewReader(f)
+	if err != nil {
+		return snapshot, fmt.Errorf("invalid test times file %s: %w", path, err)
+	}
+	if err := json.NewDecoder(zr).Decode(&snapshot); err != nil {
+		return snapshot, fmt.Errorf("invalid test times file %s: %w", path, err)
+	}
+	return snapshot, nil
+}
@@ -0,0 +1,130 @@
+package client
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// WithBandwidthLimit limits the bandwidth used by the client to upload and
+// download bytes per second, shared by all of its requests, so that large
+// callgraph uploads don't starve other traffic on shared runners. Zero
+// leaves a direction unlimited.
+func WithBandwidthLimit(upload, download int64) Option {
+	return func(c *HTTPClient) {
+		if upload <= 0 && download <= 0 {
+			c.throttle = nil
+			return
+		}
+		c.throttle = &throttleTransport{
+			upload:   newRateLimiter(upload),
+			download: newRateLimiter(download),
+		}
+	}
+}
+
+// rateLimiter is a token bucket of bytes refilled at rate bytes per second
+// and holding up to one second worth of bytes.
+type rateLimiter struct {
+	mu     sync.Mutex
+	rate   float64
+	tokens float64
+	last   time.Time
+}
+
+// newRateLimiter returns a limiter of rate bytes per second, or nil if rate
+// is not positive.
+func newRateLimiter(rate int64) *rateLimiter {
+	if rate <= 0 {
+		return nil
+	}
+	return &rateLimiter{rate: float64(rate), tokens: float64(rate), last: time.Now()}
+}
+
+// burst returns the largest number of bytes wait can be called with.
+func (l *rateLimiter) burst() int {
+	return int(l.rate)
+}
+
+// wait blocks until n bytes can be transferred or ctx is done.
+func (l *rateLimiter) wait(ctx context.Context, n int) error {
+	l.mu.Lock()
+	now := time.Now()
+	l.tokens += now.Sub(l.last).Seconds() * l.rate
+	if l.tokens > l.rate {
+		l.tokens = l.rate
+	}
+	l.last = now
+	l.tokens -= float64(n)
+	var delay time.Duration
+	if l.tokens < 0 {
+		delay = time.Duration(-l.tokens / l.rate * float64(time.Second))
+	}
+	l.mu.Unlock()
+
+	if delay == 0 {
+		return nil
+	}
+	t := time.NewTimer(delay)
+	defer t.Stop()
+	select {
+	case <-t.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// throttledReader limits the rate at which r is read.
+type throttledReader struct {
+	r   io.ReadCloser
+	lim *rateLimiter
+	ctx context.Context
+}
+
+func (t *throttledReader) Read(p []byte) (int, error) {
+	if burst := t.lim.burst(); len(p) > burst {
+		p = p[:burst]
+	}
+	n, err := t.r.Read(p)
+	if n > 0 {
+		if werr := t.lim.wait(t.ctx, n); werr != nil {
+			return n, werr
+		}
+	}
+	return n, err
+}
+
+func (t *throttledReader) Close() error {
+	return t.r.Close()
+}
+
+// throttleTransport is an http.RoundTripper limiting the bandwidth of
+// request and response bodies.
+type throttleTransport struct {
+	next     http.RoundTripper
+	upload   *rateLimiter
+	download *rateLimiter
+}
+
+// wrap decorates next with the throttling transport.
+func (t *throttleTransport) wrap(next http.RoundTripper) http.RoundTripper {
+	t.next = next
+	return t
+}
+
+func (t *throttleTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if t.upload != nil && req.Body != nil && req.Body != http.NoBody {
+		body := req.Body
+		req = req.Clone(req.Context())
+		req.Body = &throttledReader{r: body, lim: t.upload, ctx: req.Context()}
+	}
+	res, err := t.next.RoundTrip(req)
+	if err != nil || t.download == nil {
+		return res, err
+	}
+	res.Body = &throttledReader{r: res.Body, lim: t.download, ctx: req.Context()}
+	return res, nil
+}
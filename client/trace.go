@@ -0,0 +1,119 @@
+package client
+
+import (
+	"context"
+	"crypto/tls"
+	"net/http"
+	"net/http/httptrace"
+	"sync"
+	"time"
+)
+
+// ConnTimings holds the connection level timings of a single request attempt.
+type ConnTimings struct {
+	Method  string
+	URL     string // sanitized
+	Attempt int
+
+	DNS          time.Duration
+	Connect      time.Duration
+	TLSHandshake time.Duration
+	// TTFB is the time from sending the request until the first
+	// response byte was received.
+	TTFB time.Duration
+	// Reused reports whether the request was sent over a pooled connection,
+	// in which case DNS, Connect and TLSHandshake are zero.
+	Reused bool
+}
+
+// WithConnTrace registers a hook which receives the connection timings of
+// every request attempt. Timings are also logged when debug logging is on.
+func WithConnTrace(hook func(ctx context.Context, t ConnTimings)) Option {
+	return func(c *HTTPClient) {
+		c.traceHook = hook
+	}
+}
+
+// traceRequest attaches httptrace hooks to req when tracing is enabled and
+// returns the traced request along with a function reporting the timings,
+// to be called once the response headers have been received.
+func (c *HTTPClient) traceRequest(req *http.Request) (*http.Request, func()) {
+	debug := c.debugEnabled()
+	if c.traceHook == nil && !debug {
+		return req, func() {}
+	}
+
+	var (
+		mu                                 sync.Mutex
+		dnsStart, connectStart, tlsStart   time.Time
+		dns, connect, handshake, firstByte time.Duration
+		reused                             bool
+	)
+	start := time.Now()
+	trace := &httptrace.ClientTrace{
+		DNSStart: func(httptrace.DNSStartInfo) {
+			mu.Lock()
+			dnsStart = time.Now()
+			mu.Unlock()
+		},
+		DNSDone: func(httptrace.DNSDoneInfo) {
+			mu.Lock()
+			dns = time.Since(dnsStart)
+			mu.Unlock()
+		},
+		ConnectStart: func(string, string) {
+			mu.Lock()
+			connectStart = time.Now()
+			mu.Unlock()
+		},
+		ConnectDone: func(string, string, error) {
+			mu.Lock()
+			connect = time.Since(connectStart)
+			mu.Unlock()
+		},
+		TLSHandshakeStart: func() {
+			mu.Lock()
+			tlsStart = time.Now()
+			mu.Unlock()
+		},
+		TLSHandshakeDone: func(tls.ConnectionState, error) {
+			mu.Lock()
+			handshake = time.Since(tlsStart)
+			mu.Unlock()
+		},
+		GotConn: func(info httptrace.GotConnInfo) {
+			mu.Lock()
+			reused = info.Reused
+			mu.Unlock()
+		},
+		GotFirstResponseByte: func() {
+			mu.Lock()
+			firstByte = time.Since(start)
+			mu.Unlock()
+		},
+	}
+	ctx := httptrace.WithClientTrace(req.Context(), trace)
+	req = req.WithContext(ctx)
+
+	return req, func() {
+		mu.Lock()
+		t := ConnTimings{
+			Method:       req.Method,
+			URL:          SanitizeURL(req.URL.String()),
+			Attempt:      attemptFrom(ctx),
+			DNS:          dns,
+			Connect:      connect,
+			TLSHandshake: handshake,
+			TTFB:         firstByte,
+			Reused:       reused,
+		}
+		mu.Unlock()
+		if debug {
+			c.logger().Debugf("ti conn: method=%s url=%s attempt=%d dns=%s connect=%s tls=%s ttfb=%s reused=%t",
+				t.Method, t.URL, t.Attempt, t.DNS, t.Connect, t.TLSHandshake, t.TTFB, t.Reused)
+		}
+		if c.traceHook != nil {
+			c.traceHook(ctx, t)
+		}
+	}
+}
@@ -0,0 +1,42 @@
+package client
+
+import (
+	"context"
+	"net/http"
+)
+
+// TraceHeaders are the Harness platform and W3C trace context headers
+// propagated from incoming requests to TI requests, so that TI calls show
+// up in end-to-end platform traces.
+var TraceHeaders = []string{"X-Harness-Trace-ID", "X-Harness-Span-ID", "Traceparent", "Tracestate", "Baggage"}
+
+type traceHeadersKey struct{}
+
+// WithTraceHeaders returns a copy of ctx carrying the trace headers found
+// in md, the headers of an incoming HTTP request or the metadata of an
+// incoming gRPC call. Requests made with the returned context, including
+// all of their retries, carry the same headers.
+func WithTraceHeaders(ctx context.Context, md map[string][]string) context.Context {
+	h := make(http.Header)
+	for k, v := range md {
+		key := http.CanonicalHeaderKey(k)
+		for _, name := range TraceHeaders {
+			if key == http.CanonicalHeaderKey(name) && len(v) > 0 {
+				h[key] = append([]string(nil), v...)
+			}
+		}
+	}
+	if len(h) == 0 {
+		return ctx
+	}
+	return context.WithValue(ctx, traceHeadersKey{}, h)
+}
+
+// setTraceHeaders copies the trace headers carried by the context of req
+// to req.
+func setTraceHeaders(req *http.Request) {
+	h, _ := req.Context().Value(traceHeadersKey{}).(http.Header)
+	for k, v := range h {
+		req.Header[k] = v
+	}
+}
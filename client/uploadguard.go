@@ -0,0 +1,68 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+)
+
+// ErrUploadInProgress is returned by UploadCg, if enabled with
+// WithUploadInProgressError, when an upload of the same callgraph for the
+// same step, repository and commit is already in flight in this process.
+var ErrUploadInProgress = errors.New("callgraph upload already in progress")
+
+// WithUploadInProgressError makes concurrent duplicate UploadCg calls fail
+// with ErrUploadInProgress instead of waiting for the in-flight upload and
+// returning its result.
+func WithUploadInProgressError() Option {
+	return func(c *HTTPClient) {
+		c.failDupUploads = true
+	}
+}
+
+// uploadGuard deduplicates concurrent uploads with the same key. The zero
+// value is ready to use.
+type uploadGuard struct {
+	mu       sync.Mutex
+	inflight map[string]*inflightUpload
+}
+
+type inflightUpload struct {
+	done chan struct{}
+	err  error
+}
+
+// do runs fn unless an upload with the same key is in flight, in which
+// case it returns ErrUploadInProgress if fail is set, or waits for the
+// in-flight upload and returns its error.
+func (g *uploadGuard) do(ctx context.Context, key string, fail bool, fn func() error) error {
+	g.mu.Lock()
+	if u, ok := g.inflight[key]; ok {
+		g.mu.Unlock()
+		if fail {
+			return fmt.Errorf("%w: %s", ErrUploadInProgress, key)
+		}
+		select {
+		case <-u.done:
+			return u.err
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+	if g.inflight == nil {
+		g.inflight = map[string]*inflightUpload{}
+	}
+	u := &inflightUpload{done: make(chan struct{})}
+	g.inflight[key] = u
+	g.mu.Unlock()
+
+	defer func() {
+		g.mu.Lock()
+		delete(g.inflight, key)
+		g.mu.Unlock()
+		close(u.done)
+	}()
+	u.err = fn()
+	return u.err
+}
@@ -0,0 +1,60 @@
+package client
+
+import (
+	"fmt"
+	"runtime"
+	"runtime/debug"
+	"strings"
+)
+
+const modulePath = "github.com/harness/ti-client"
+
+// Version is the version of the ti-client module reported in the
+// User-Agent header. It is resolved from the build info of the binary
+// embedding this module and falls back to "devel".
+var Version = moduleVersion()
+
+func moduleVersion() string {
+	info, ok := debug.ReadBuildInfo()
+	if !ok {
+		return "devel"
+	}
+	if info.Main.Path == modulePath && info.Main.Version != "" && info.Main.Version != "(devel)" {
+		return info.Main.Version
+	}
+	for _, dep := range info.Deps {
+		if dep.Path != modulePath {
+			continue
+		}
+		if dep.Replace != nil && dep.Replace.Version != "" {
+			return dep.Replace.Version
+		}
+		return dep.Version
+	}
+	return "devel"
+}
+
+// WithUserAgent appends the calling component name and version to the
+// User-Agent header sent with every request,
+// e.g. ti-client/v0.1.0 (lite-engine/1.2.3; go1.20; linux/amd64).
+func WithUserAgent(component, version string) Option {
+	return func(c *HTTPClient) {
+		caller := component
+		if version != "" {
+			caller = component + "/" + version
+		}
+		c.userAgent = userAgent(caller)
+	}
+}
+
+// userAgent returns the structured User-Agent for the given caller.
+func userAgent(caller string) string {
+	details := []string{
+		runtime.Version(),
+		runtime.GOOS + "/" + runtime.GOARCH,
+	}
+	if caller != "" {
+		details = append([]string{caller}, details...)
+	}
+	return fmt.Sprintf("ti-client/%s (%s)", Version, strings.Join(details, "; "))
+}
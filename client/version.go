@@ -0,0 +1,57 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"strings"
+)
+
+// apiVersionHeader carries the TI API version requested by the client.
+const apiVersionHeader = "X-Harness-TI-API-Version"
+
+// WithBasePath prefixes every endpoint path with prefix, e.g. /ti/api/v2,
+// for deployments behind a gateway.
+func WithBasePath(prefix string) Option {
+	return func(c *HTTPClient) {
+		c.basePath = "/" + strings.Trim(prefix, "/")
+		if c.basePath == "/" {
+			c.basePath = ""
+		}
+	}
+}
+
+// WithAPIVersion sends the given TI API version with every request.
+func WithAPIVersion(version string) Option {
+	return func(c *HTTPClient) {
+		c.apiVersion = version
+	}
+}
+
+// APIVersion returns the TI API version sent with requests, if any.
+func (c *HTTPClient) APIVersion() string {
+	return c.apiVersion
+}
+
+// NegotiateAPIVersion picks the first of the preferred API versions that the
+// server advertises and sends it with all subsequent requests. Servers which
+// do not advertise API versions keep the current version. It is meant to be
+// called right after construction, before the client is shared.
+func (c *HTTPClient) NegotiateAPIVersion(ctx context.Context, preferred ...string) (string, error) {
+	info, err := c.HealthzInfo(ctx)
+	if err != nil {
+		return c.apiVersion, err
+	}
+	if len(info.APIVersions) == 0 {
+		return c.apiVersion, nil
+	}
+	for _, v := range preferred {
+		for _, supported := range info.APIVersions {
+			if v == supported {
+				c.apiVersion = v
+				return v, nil
+			}
+		}
+	}
+	return c.apiVersion, fmt.Errorf("server supports API versions %s, none of %s",
+		strings.Join(info.APIVersions, ", "), strings.Join(preferred, ", "))
+}
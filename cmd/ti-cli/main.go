@@ -0,0 +1,81 @@
+// Command ti-cli is a command line companion of the TI client, meant to
+// troubleshoot agents talking to the TI service.
+//
+// Usage:
+//
+//	ti-cli diagnose [flags]
+//
+// The diagnose subcommand checks the connectivity to the TI service and
+// prints a report to paste into support tickets. It exits with status 1
+// if any check fails.
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/harness/ti-client/client"
+)
+
+func main() {
+	if len(os.Args) < 2 {
+		usage()
+		os.Exit(2)
+	}
+	switch os.Args[1] {
+	case "diagnose":
+		os.Exit(diagnose(os.Args[2:]))
+	case "help", "-h", "-help", "--help":
+		usage()
+	default:
+		fmt.Fprintf(os.Stderr, "ti-cli: unknown command %q\n", os.Args[1])
+		usage()
+		os.Exit(2)
+	}
+}
+
+func usage() {
+	fmt.Fprintln(os.Stderr, "usage: ti-cli <command> [flags]")
+	fmt.Fprintln(os.Stderr, "")
+	fmt.Fprintln(os.Stderr, "commands:")
+	fmt.Fprintln(os.Stderr, "  diagnose  check the connectivity to the TI service")
+}
+
+// diagnose runs the diagnose subcommand and returns the exit status.
+func diagnose(args []string) int {
+	fs := flag.NewFlagSet("diagnose", flag.ExitOnError)
+	endpoint := fs.String("endpoint", os.Getenv("HARNESS_TI_SERVICE_ENDPOINT"), "TI service endpoint")
+	token := fs.String("token", os.Getenv("HARNESS_TI_SERVICE_TOKEN"), "TI service token")
+	account := fs.String("account", os.Getenv("HARNESS_ACCOUNT_ID"), "account id")
+	org := fs.String("org", os.Getenv("HARNESS_ORG_ID"), "organization id")
+	project := fs.String("project", os.Getenv("HARNESS_PROJECT_ID"), "project id")
+	skipVerify := fs.Bool("skip-verify", false, "skip the verification of the server certificate")
+	certsDir := fs.String("certs-dir", os.Getenv("HARNESS_ADDITIONAL_CERTS_DIR"), "directory of additional root certificates")
+	timeout := fs.Duration("timeout", 30*time.Second, "timeout of the whole diagnosis")
+	asJSON := fs.Bool("json", false, "print the report as JSON")
+	fs.Parse(args) //nolint:errcheck
+
+	if *endpoint == "" {
+		fmt.Fprintln(os.Stderr, "ti-cli: the endpoint is not set, use -endpoint or HARNESS_TI_SERVICE_ENDPOINT")
+		return 2
+	}
+	c := client.NewHTTPClient(*endpoint, *token, *account, *org, *project, "", "", "", "", "", "", *skipVerify, *certsDir)
+	ctx, cancel := context.WithTimeout(context.Background(), *timeout)
+	defer cancel()
+	report := c.Diagnose(ctx)
+	if *asJSON {
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		enc.Encode(report) //nolint:errcheck
+	} else {
+		fmt.Print(report.String())
+	}
+	if !report.OK() {
+		return 1
+	}
+	return 0
+}
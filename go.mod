@@ -3,7 +3,11 @@ module github.com/harness/ti-client
 go 1.20
 
 require (
-	github.com/cenkalti/backoff v2.2.1+incompatible // indirect
+	github.com/cenkalti/backoff v2.2.1+incompatible
+	google.golang.org/protobuf v1.31.0
+)
+
+require (
 	github.com/sirupsen/logrus v1.9.0 // indirect
 	golang.org/x/sys v0.0.0-20220715151400-c0bba94af5f8 // indirect
 )
@@ -0,0 +1,5 @@
+// Package tiv1 contains the Go bindings of the TI wire schema defined in
+// ti.proto, generated with protoc-gen-go.
+package tiv1
+
+//go:generate protoc -I ../../.. --go_out=../../.. --go_opt=paths=source_relative ../../../proto/ti/v1/ti.proto
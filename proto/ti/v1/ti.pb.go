@@ -0,0 +1,2002 @@
+// Wire schema of the core Test Intelligence types, shared by agents written
+// in languages other than Go. Field names match the JSON encoding used by
+// the Go types in github.com/harness/ti-client/types.
+
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.31.0
+// 	protoc        v4.25.3
+// source: proto/ti/v1/ti.proto
+
+package tiv1
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// Result of a test case run (types.Result).
+type Result struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Status  string `protobuf:"bytes,1,opt,name=status,proto3" json:"status,omitempty"` // passed, skipped, failed or error
+	Message string `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	Type    string `protobuf:"bytes,3,opt,name=type,proto3" json:"type,omitempty"`
+	Desc    string `protobuf:"bytes,4,opt,name=desc,proto3" json:"desc,omitempty"`
+}
+
+func (x *Result) Reset() {
+	*x = Result{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_ti_v1_ti_proto_msgTypes[0]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Result) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Result) ProtoMessage() {}
+
+func (x *Result) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_ti_v1_ti_proto_msgTypes[0]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Result.ProtoReflect.Descriptor instead.
+func (*Result) Descriptor() ([]byte, []int) {
+	return file_proto_ti_v1_ti_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *Result) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *Result) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *Result) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *Result) GetDesc() string {
+	if x != nil {
+		return x.Desc
+	}
+	return ""
+}
+
+// Result of one invocation of a parameterized test (types.ParameterResult).
+type ParameterResult struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Name       string  `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Result     *Result `protobuf:"bytes,2,opt,name=result,proto3" json:"result,omitempty"`
+	DurationMs int64   `protobuf:"varint,3,opt,name=duration_ms,json=durationMs,proto3" json:"duration_ms,omitempty"`
+	Stdout     string  `protobuf:"bytes,4,opt,name=stdout,proto3" json:"stdout,omitempty"`
+	Stderr     string  `protobuf:"bytes,5,opt,name=stderr,proto3" json:"stderr,omitempty"`
+}
+
+func (x *ParameterResult) Reset() {
+	*x = ParameterResult{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_ti_v1_ti_proto_msgTypes[1]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ParameterResult) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ParameterResult) ProtoMessage() {}
+
+func (x *ParameterResult) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_ti_v1_ti_proto_msgTypes[1]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ParameterResult.ProtoReflect.Descriptor instead.
+func (*ParameterResult) Descriptor() ([]byte, []int) {
+	return file_proto_ti_v1_ti_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *ParameterResult) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *ParameterResult) GetResult() *Result {
+	if x != nil {
+		return x.Result
+	}
+	return nil
+}
+
+func (x *ParameterResult) GetDurationMs() int64 {
+	if x != nil {
+		return x.DurationMs
+	}
+	return 0
+}
+
+func (x *ParameterResult) GetStdout() string {
+	if x != nil {
+		return x.Stdout
+	}
+	return ""
+}
+
+func (x *ParameterResult) GetStderr() string {
+	if x != nil {
+		return x.Stderr
+	}
+	return ""
+}
+
+// A test case run (types.TestCase).
+type TestCase struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Name         string             `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	ClassName    string             `protobuf:"bytes,2,opt,name=class_name,json=className,proto3" json:"class_name,omitempty"`
+	FileName     string             `protobuf:"bytes,3,opt,name=file_name,json=fileName,proto3" json:"file_name,omitempty"`
+	SuiteName    string             `protobuf:"bytes,4,opt,name=suite_name,json=suiteName,proto3" json:"suite_name,omitempty"`
+	Result       *Result            `protobuf:"bytes,5,opt,name=result,proto3" json:"result,omitempty"`
+	DurationMs   int64              `protobuf:"varint,6,opt,name=duration_ms,json=durationMs,proto3" json:"duration_ms,omitempty"`
+	Stdout       string             `protobuf:"bytes,7,opt,name=stdout,proto3" json:"stdout,omitempty"`
+	Stderr       string             `protobuf:"bytes,8,opt,name=stderr,proto3" json:"stderr,omitempty"`
+	Muted        bool               `protobuf:"varint,9,opt,name=muted,proto3" json:"muted,omitempty"`
+	StartTimeMs  int64              `protobuf:"varint,10,opt,name=start_time_ms,json=startTimeMs,proto3" json:"start_time_ms,omitempty"` // unix milliseconds, unset if unknown
+	Issues       []string           `protobuf:"bytes,11,rep,name=issues,proto3" json:"issues,omitempty"`                                 // keys of the issues or requirements covered
+	Quarantined  bool               `protobuf:"varint,12,opt,name=quarantined,proto3" json:"quarantined,omitempty"`
+	Parameters   []*ParameterResult `protobuf:"bytes,13,rep,name=parameters,proto3" json:"parameters,omitempty"`
+	SuitePhase   string             `protobuf:"bytes,14,opt,name=suite_phase,json=suitePhase,proto3" json:"suite_phase,omitempty"`         // setup or teardown for suite failures
+	SampleWeight float64            `protobuf:"fixed64,15,opt,name=sample_weight,json=sampleWeight,proto3" json:"sample_weight,omitempty"` // passed tests a sampled passed test stands for
+}
+
+func (x *TestCase) Reset() {
+	*x = TestCase{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_ti_v1_ti_proto_msgTypes[2]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *TestCase) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TestCase) ProtoMessage() {}
+
+func (x *TestCase) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_ti_v1_ti_proto_msgTypes[2]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TestCase.ProtoReflect.Descriptor instead.
+func (*TestCase) Descriptor() ([]byte, []int) {
+	return file_proto_ti_v1_ti_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *TestCase) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *TestCase) GetClassName() string {
+	if x != nil {
+		return x.ClassName
+	}
+	return ""
+}
+
+func (x *TestCase) GetFileName() string {
+	if x != nil {
+		return x.FileName
+	}
+	return ""
+}
+
+func (x *TestCase) GetSuiteName() string {
+	if x != nil {
+		return x.SuiteName
+	}
+	return ""
+}
+
+func (x *TestCase) GetResult() *Result {
+	if x != nil {
+		return x.Result
+	}
+	return nil
+}
+
+func (x *TestCase) GetDurationMs() int64 {
+	if x != nil {
+		return x.DurationMs
+	}
+	return 0
+}
+
+func (x *TestCase) GetStdout() string {
+	if x != nil {
+		return x.Stdout
+	}
+	return ""
+}
+
+func (x *TestCase) GetStderr() string {
+	if x != nil {
+		return x.Stderr
+	}
+	return ""
+}
+
+func (x *TestCase) GetMuted() bool {
+	if x != nil {
+		return x.Muted
+	}
+	return false
+}
+
+func (x *TestCase) GetStartTimeMs() int64 {
+	if x != nil {
+		return x.StartTimeMs
+	}
+	return 0
+}
+
+func (x *TestCase) GetIssues() []string {
+	if x != nil {
+		return x.Issues
+	}
+	return nil
+}
+
+func (x *TestCase) GetQuarantined() bool {
+	if x != nil {
+		return x.Quarantined
+	}
+	return false
+}
+
+func (x *TestCase) GetParameters() []*ParameterResult {
+	if x != nil {
+		return x.Parameters
+	}
+	return nil
+}
+
+func (x *TestCase) GetSuitePhase() string {
+	if x != nil {
+		return x.SuitePhase
+	}
+	return ""
+}
+
+func (x *TestCase) GetSampleWeight() float64 {
+	if x != nil {
+		return x.SampleWeight
+	}
+	return 0
+}
+
+// A changed file (types.File).
+type File struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Name    string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Status  string `protobuf:"bytes,2,opt,name=status,proto3" json:"status,omitempty"` // modified, added or deleted
+	Package string `protobuf:"bytes,3,opt,name=package,proto3" json:"package,omitempty"`
+}
+
+func (x *File) Reset() {
+	*x = File{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_ti_v1_ti_proto_msgTypes[3]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *File) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*File) ProtoMessage() {}
+
+func (x *File) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_ti_v1_ti_proto_msgTypes[3]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use File.ProtoReflect.Descriptor instead.
+func (*File) Descriptor() ([]byte, []int) {
+	return file_proto_ti_v1_ti_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *File) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *File) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *File) GetPackage() string {
+	if x != nil {
+		return x.Package
+	}
+	return ""
+}
+
+// Contents of the .ticonfig file (types.TiConfig).
+type TiConfig struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Config *TiConfig_Config `protobuf:"bytes,1,opt,name=config,proto3" json:"config,omitempty"`
+}
+
+func (x *TiConfig) Reset() {
+	*x = TiConfig{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_ti_v1_ti_proto_msgTypes[4]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *TiConfig) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TiConfig) ProtoMessage() {}
+
+func (x *TiConfig) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_ti_v1_ti_proto_msgTypes[4]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TiConfig.ProtoReflect.Descriptor instead.
+func (*TiConfig) Descriptor() ([]byte, []int) {
+	return file_proto_ti_v1_ti_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *TiConfig) GetConfig() *TiConfig_Config {
+	if x != nil {
+		return x.Config
+	}
+	return nil
+}
+
+// A dependency added, removed or bumped in a lockfile
+// (types.DependencyChange).
+type DependencyChange struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Lockfile   string `protobuf:"bytes,1,opt,name=lockfile,proto3" json:"lockfile,omitempty"`
+	Name       string `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	OldVersion string `protobuf:"bytes,3,opt,name=old_version,json=oldVersion,proto3" json:"old_version,omitempty"` // empty for added dependencies
+	NewVersion string `protobuf:"bytes,4,opt,name=new_version,json=newVersion,proto3" json:"new_version,omitempty"` // empty for removed dependencies
+}
+
+func (x *DependencyChange) Reset() {
+	*x = DependencyChange{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_ti_v1_ti_proto_msgTypes[5]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *DependencyChange) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DependencyChange) ProtoMessage() {}
+
+func (x *DependencyChange) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_ti_v1_ti_proto_msgTypes[5]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DependencyChange.ProtoReflect.Descriptor instead.
+func (*DependencyChange) Descriptor() ([]byte, []int) {
+	return file_proto_ti_v1_ti_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *DependencyChange) GetLockfile() string {
+	if x != nil {
+		return x.Lockfile
+	}
+	return ""
+}
+
+func (x *DependencyChange) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *DependencyChange) GetOldVersion() string {
+	if x != nil {
+		return x.OldVersion
+	}
+	return ""
+}
+
+func (x *DependencyChange) GetNewVersion() string {
+	if x != nil {
+		return x.NewVersion
+	}
+	return ""
+}
+
+// Pull request a build ran for (types.PRMetadata).
+type PRMetadata struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Number int32    `protobuf:"varint,1,opt,name=number,proto3" json:"number,omitempty"`
+	Title  string   `protobuf:"bytes,2,opt,name=title,proto3" json:"title,omitempty"`
+	Author string   `protobuf:"bytes,3,opt,name=author,proto3" json:"author,omitempty"`
+	Labels []string `protobuf:"bytes,4,rep,name=labels,proto3" json:"labels,omitempty"`
+	Link   string   `protobuf:"bytes,5,opt,name=link,proto3" json:"link,omitempty"` // web link of the pull request or of its head commit
+}
+
+func (x *PRMetadata) Reset() {
+	*x = PRMetadata{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_ti_v1_ti_proto_msgTypes[6]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *PRMetadata) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PRMetadata) ProtoMessage() {}
+
+func (x *PRMetadata) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_ti_v1_ti_proto_msgTypes[6]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PRMetadata.ProtoReflect.Descriptor instead.
+func (*PRMetadata) Descriptor() ([]byte, []int) {
+	return file_proto_ti_v1_ti_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *PRMetadata) GetNumber() int32 {
+	if x != nil {
+		return x.Number
+	}
+	return 0
+}
+
+func (x *PRMetadata) GetTitle() string {
+	if x != nil {
+		return x.Title
+	}
+	return ""
+}
+
+func (x *PRMetadata) GetAuthor() string {
+	if x != nil {
+		return x.Author
+	}
+	return ""
+}
+
+func (x *PRMetadata) GetLabels() []string {
+	if x != nil {
+		return x.Labels
+	}
+	return nil
+}
+
+func (x *PRMetadata) GetLink() string {
+	if x != nil {
+		return x.Link
+	}
+	return ""
+}
+
+// Test selection request (types.SelectTestsReq).
+type SelectTestsReq struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	SelectAll           bool                `protobuf:"varint,1,opt,name=select_all,json=selectAll,proto3" json:"select_all,omitempty"`
+	Files               []*File             `protobuf:"bytes,2,rep,name=files,proto3" json:"files,omitempty"`
+	SourceBranch        string              `protobuf:"bytes,3,opt,name=source_branch,json=sourceBranch,proto3" json:"source_branch,omitempty"`
+	TargetBranch        string              `protobuf:"bytes,4,opt,name=target_branch,json=targetBranch,proto3" json:"target_branch,omitempty"`
+	Repo                string              `protobuf:"bytes,5,opt,name=repo,proto3" json:"repo,omitempty"`
+	TiConfig            *TiConfig           `protobuf:"bytes,6,opt,name=ti_config,json=tiConfig,proto3" json:"ti_config,omitempty"`
+	TestGlobs           []string            `protobuf:"bytes,7,rep,name=test_globs,json=testGlobs,proto3" json:"test_globs,omitempty"`
+	Language            string              `protobuf:"bytes,8,opt,name=language,proto3" json:"language,omitempty"`
+	ChangedDependencies []*DependencyChange `protobuf:"bytes,9,rep,name=changed_dependencies,json=changedDependencies,proto3" json:"changed_dependencies,omitempty"`
+	Pr                  *PRMetadata         `protobuf:"bytes,10,opt,name=pr,proto3" json:"pr,omitempty"`
+}
+
+func (x *SelectTestsReq) Reset() {
+	*x = SelectTestsReq{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_ti_v1_ti_proto_msgTypes[7]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SelectTestsReq) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SelectTestsReq) ProtoMessage() {}
+
+func (x *SelectTestsReq) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_ti_v1_ti_proto_msgTypes[7]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SelectTestsReq.ProtoReflect.Descriptor instead.
+func (*SelectTestsReq) Descriptor() ([]byte, []int) {
+	return file_proto_ti_v1_ti_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *SelectTestsReq) GetSelectAll() bool {
+	if x != nil {
+		return x.SelectAll
+	}
+	return false
+}
+
+func (x *SelectTestsReq) GetFiles() []*File {
+	if x != nil {
+		return x.Files
+	}
+	return nil
+}
+
+func (x *SelectTestsReq) GetSourceBranch() string {
+	if x != nil {
+		return x.SourceBranch
+	}
+	return ""
+}
+
+func (x *SelectTestsReq) GetTargetBranch() string {
+	if x != nil {
+		return x.TargetBranch
+	}
+	return ""
+}
+
+func (x *SelectTestsReq) GetRepo() string {
+	if x != nil {
+		return x.Repo
+	}
+	return ""
+}
+
+func (x *SelectTestsReq) GetTiConfig() *TiConfig {
+	if x != nil {
+		return x.TiConfig
+	}
+	return nil
+}
+
+func (x *SelectTestsReq) GetTestGlobs() []string {
+	if x != nil {
+		return x.TestGlobs
+	}
+	return nil
+}
+
+func (x *SelectTestsReq) GetLanguage() string {
+	if x != nil {
+		return x.Language
+	}
+	return ""
+}
+
+func (x *SelectTestsReq) GetChangedDependencies() []*DependencyChange {
+	if x != nil {
+		return x.ChangedDependencies
+	}
+	return nil
+}
+
+func (x *SelectTestsReq) GetPr() *PRMetadata {
+	if x != nil {
+		return x.Pr
+	}
+	return nil
+}
+
+// Recent results of a test (types.TestHistory).
+type TestHistory struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Runs   int32    `protobuf:"varint,1,opt,name=runs,proto3" json:"runs,omitempty"`
+	Passed int32    `protobuf:"varint,2,opt,name=passed,proto3" json:"passed,omitempty"`
+	Failed int32    `protobuf:"varint,3,opt,name=failed,proto3" json:"failed,omitempty"`
+	Last   []string `protobuf:"bytes,4,rep,name=last,proto3" json:"last,omitempty"` // statuses of the last runs, most recent first
+}
+
+func (x *TestHistory) Reset() {
+	*x = TestHistory{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_ti_v1_ti_proto_msgTypes[8]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *TestHistory) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TestHistory) ProtoMessage() {}
+
+func (x *TestHistory) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_ti_v1_ti_proto_msgTypes[8]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TestHistory.ProtoReflect.Descriptor instead.
+func (*TestHistory) Descriptor() ([]byte, []int) {
+	return file_proto_ti_v1_ti_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *TestHistory) GetRuns() int32 {
+	if x != nil {
+		return x.Runs
+	}
+	return 0
+}
+
+func (x *TestHistory) GetPassed() int32 {
+	if x != nil {
+		return x.Passed
+	}
+	return 0
+}
+
+func (x *TestHistory) GetFailed() int32 {
+	if x != nil {
+		return x.Failed
+	}
+	return 0
+}
+
+func (x *TestHistory) GetLast() []string {
+	if x != nil {
+		return x.Last
+	}
+	return nil
+}
+
+// A test to run (types.RunnableTest).
+type RunnableTest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Pkg        string                   `protobuf:"bytes,1,opt,name=pkg,proto3" json:"pkg,omitempty"`
+	Class      string                   `protobuf:"bytes,2,opt,name=class,proto3" json:"class,omitempty"`
+	Method     string                   `protobuf:"bytes,3,opt,name=method,proto3" json:"method,omitempty"`
+	Selection  string                   `protobuf:"bytes,4,opt,name=selection,proto3" json:"selection,omitempty"` // reason the test was selected
+	Autodetect *RunnableTest_Autodetect `protobuf:"bytes,5,opt,name=autodetect,proto3" json:"autodetect,omitempty"`
+	Confidence *float64                 `protobuf:"fixed64,6,opt,name=confidence,proto3,oneof" json:"confidence,omitempty"` // 0-1, set by ML based selection
+	Score      float64                  `protobuf:"fixed64,7,opt,name=score,proto3" json:"score,omitempty"`                 // higher runs first
+	Flakiness  *float64                 `protobuf:"fixed64,8,opt,name=flakiness,proto3,oneof" json:"flakiness,omitempty"`   // 0-1, fraction of runs with inconsistent results
+	History    *TestHistory             `protobuf:"bytes,9,opt,name=history,proto3" json:"history,omitempty"`
+}
+
+func (x *RunnableTest) Reset() {
+	*x = RunnableTest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_ti_v1_ti_proto_msgTypes[9]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *RunnableTest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RunnableTest) ProtoMessage() {}
+
+func (x *RunnableTest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_ti_v1_ti_proto_msgTypes[9]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RunnableTest.ProtoReflect.Descriptor instead.
+func (*RunnableTest) Descriptor() ([]byte, []int) {
+	return file_proto_ti_v1_ti_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *RunnableTest) GetPkg() string {
+	if x != nil {
+		return x.Pkg
+	}
+	return ""
+}
+
+func (x *RunnableTest) GetClass() string {
+	if x != nil {
+		return x.Class
+	}
+	return ""
+}
+
+func (x *RunnableTest) GetMethod() string {
+	if x != nil {
+		return x.Method
+	}
+	return ""
+}
+
+func (x *RunnableTest) GetSelection() string {
+	if x != nil {
+		return x.Selection
+	}
+	return ""
+}
+
+func (x *RunnableTest) GetAutodetect() *RunnableTest_Autodetect {
+	if x != nil {
+		return x.Autodetect
+	}
+	return nil
+}
+
+func (x *RunnableTest) GetConfidence() float64 {
+	if x != nil && x.Confidence != nil {
+		return *x.Confidence
+	}
+	return 0
+}
+
+func (x *RunnableTest) GetScore() float64 {
+	if x != nil {
+		return x.Score
+	}
+	return 0
+}
+
+func (x *RunnableTest) GetFlakiness() float64 {
+	if x != nil && x.Flakiness != nil {
+		return *x.Flakiness
+	}
+	return 0
+}
+
+func (x *RunnableTest) GetHistory() *TestHistory {
+	if x != nil {
+		return x.History
+	}
+	return nil
+}
+
+// Test selection response (types.SelectTestsResp).
+type SelectTestsResp struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	TotalTests    int32           `protobuf:"varint,1,opt,name=total_tests,json=totalTests,proto3" json:"total_tests,omitempty"`
+	SelectedTests int32           `protobuf:"varint,2,opt,name=selected_tests,json=selectedTests,proto3" json:"selected_tests,omitempty"`
+	NewTests      int32           `protobuf:"varint,3,opt,name=new_tests,json=newTests,proto3" json:"new_tests,omitempty"`
+	UpdatedTests  int32           `protobuf:"varint,4,opt,name=updated_tests,json=updatedTests,proto3" json:"updated_tests,omitempty"`
+	SrcCodeTests  int32           `protobuf:"varint,5,opt,name=src_code_tests,json=srcCodeTests,proto3" json:"src_code_tests,omitempty"`
+	SelectAll     bool            `protobuf:"varint,6,opt,name=select_all,json=selectAll,proto3" json:"select_all,omitempty"`
+	Tests         []*RunnableTest `protobuf:"bytes,7,rep,name=tests,proto3" json:"tests,omitempty"`
+	Confidence    *float64        `protobuf:"fixed64,8,opt,name=confidence,proto3,oneof" json:"confidence,omitempty"` // 0-1, set by ML based selection
+}
+
+func (x *SelectTestsResp) Reset() {
+	*x = SelectTestsResp{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_ti_v1_ti_proto_msgTypes[10]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SelectTestsResp) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SelectTestsResp) ProtoMessage() {}
+
+func (x *SelectTestsResp) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_ti_v1_ti_proto_msgTypes[10]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SelectTestsResp.ProtoReflect.Descriptor instead.
+func (*SelectTestsResp) Descriptor() ([]byte, []int) {
+	return file_proto_ti_v1_ti_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *SelectTestsResp) GetTotalTests() int32 {
+	if x != nil {
+		return x.TotalTests
+	}
+	return 0
+}
+
+func (x *SelectTestsResp) GetSelectedTests() int32 {
+	if x != nil {
+		return x.SelectedTests
+	}
+	return 0
+}
+
+func (x *SelectTestsResp) GetNewTests() int32 {
+	if x != nil {
+		return x.NewTests
+	}
+	return 0
+}
+
+func (x *SelectTestsResp) GetUpdatedTests() int32 {
+	if x != nil {
+		return x.UpdatedTests
+	}
+	return 0
+}
+
+func (x *SelectTestsResp) GetSrcCodeTests() int32 {
+	if x != nil {
+		return x.SrcCodeTests
+	}
+	return 0
+}
+
+func (x *SelectTestsResp) GetSelectAll() bool {
+	if x != nil {
+		return x.SelectAll
+	}
+	return false
+}
+
+func (x *SelectTestsResp) GetTests() []*RunnableTest {
+	if x != nil {
+		return x.Tests
+	}
+	return nil
+}
+
+func (x *SelectTestsResp) GetConfidence() float64 {
+	if x != nil && x.Confidence != nil {
+		return *x.Confidence
+	}
+	return 0
+}
+
+// Callgraph node used for visualization (types.VisNode).
+type CallgraphNode struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Id        int32  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Package   string `protobuf:"bytes,2,opt,name=package,proto3" json:"package,omitempty"`
+	Class     string `protobuf:"bytes,3,opt,name=class,proto3" json:"class,omitempty"`
+	File      string `protobuf:"bytes,4,opt,name=file,proto3" json:"file,omitempty"`
+	Type      string `protobuf:"bytes,5,opt,name=type,proto3" json:"type,omitempty"`
+	Root      bool   `protobuf:"varint,6,opt,name=root,proto3" json:"root,omitempty"`
+	Important bool   `protobuf:"varint,7,opt,name=important,proto3" json:"important,omitempty"`
+}
+
+func (x *CallgraphNode) Reset() {
+	*x = CallgraphNode{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_ti_v1_ti_proto_msgTypes[11]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CallgraphNode) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CallgraphNode) ProtoMessage() {}
+
+func (x *CallgraphNode) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_ti_v1_ti_proto_msgTypes[11]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CallgraphNode.ProtoReflect.Descriptor instead.
+func (*CallgraphNode) Descriptor() ([]byte, []int) {
+	return file_proto_ti_v1_ti_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *CallgraphNode) GetId() int32 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *CallgraphNode) GetPackage() string {
+	if x != nil {
+		return x.Package
+	}
+	return ""
+}
+
+func (x *CallgraphNode) GetClass() string {
+	if x != nil {
+		return x.Class
+	}
+	return ""
+}
+
+func (x *CallgraphNode) GetFile() string {
+	if x != nil {
+		return x.File
+	}
+	return ""
+}
+
+func (x *CallgraphNode) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *CallgraphNode) GetRoot() bool {
+	if x != nil {
+		return x.Root
+	}
+	return false
+}
+
+func (x *CallgraphNode) GetImportant() bool {
+	if x != nil {
+		return x.Important
+	}
+	return false
+}
+
+// Edges from one callgraph node (types.VisMapping).
+type CallgraphEdge struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	From int32   `protobuf:"varint,1,opt,name=from,proto3" json:"from,omitempty"`
+	To   []int32 `protobuf:"varint,2,rep,packed,name=to,proto3" json:"to,omitempty"`
+}
+
+func (x *CallgraphEdge) Reset() {
+	*x = CallgraphEdge{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_ti_v1_ti_proto_msgTypes[12]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CallgraphEdge) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CallgraphEdge) ProtoMessage() {}
+
+func (x *CallgraphEdge) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_ti_v1_ti_proto_msgTypes[12]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CallgraphEdge.ProtoReflect.Descriptor instead.
+func (*CallgraphEdge) Descriptor() ([]byte, []int) {
+	return file_proto_ti_v1_ti_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *CallgraphEdge) GetFrom() int32 {
+	if x != nil {
+		return x.From
+	}
+	return 0
+}
+
+func (x *CallgraphEdge) GetTo() []int32 {
+	if x != nil {
+		return x.To
+	}
+	return nil
+}
+
+// Callgraph (types.GetVgResp).
+type Callgraph struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Nodes []*CallgraphNode `protobuf:"bytes,1,rep,name=nodes,proto3" json:"nodes,omitempty"`
+	Edges []*CallgraphEdge `protobuf:"bytes,2,rep,name=edges,proto3" json:"edges,omitempty"`
+}
+
+func (x *Callgraph) Reset() {
+	*x = Callgraph{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_ti_v1_ti_proto_msgTypes[13]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Callgraph) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Callgraph) ProtoMessage() {}
+
+func (x *Callgraph) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_ti_v1_ti_proto_msgTypes[13]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Callgraph.ProtoReflect.Descriptor instead.
+func (*Callgraph) Descriptor() ([]byte, []int) {
+	return file_proto_ti_v1_ti_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *Callgraph) GetNodes() []*CallgraphNode {
+	if x != nil {
+		return x.Nodes
+	}
+	return nil
+}
+
+func (x *Callgraph) GetEdges() []*CallgraphEdge {
+	if x != nil {
+		return x.Edges
+	}
+	return nil
+}
+
+// Test known to chrysalis (chrysalis.Test).
+type ChrysalisTest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Key      string                 `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
+	Path     string                 `protobuf:"bytes,2,opt,name=path,proto3" json:"path,omitempty"`
+	ExpireAt *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=expire_at,json=expireAt,proto3" json:"expire_at,omitempty"` // unset if it never expires
+}
+
+func (x *ChrysalisTest) Reset() {
+	*x = ChrysalisTest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_ti_v1_ti_proto_msgTypes[14]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ChrysalisTest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ChrysalisTest) ProtoMessage() {}
+
+func (x *ChrysalisTest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_ti_v1_ti_proto_msgTypes[14]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ChrysalisTest.ProtoReflect.Descriptor instead.
+func (*ChrysalisTest) Descriptor() ([]byte, []int) {
+	return file_proto_ti_v1_ti_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *ChrysalisTest) GetKey() string {
+	if x != nil {
+		return x.Key
+	}
+	return ""
+}
+
+func (x *ChrysalisTest) GetPath() string {
+	if x != nil {
+		return x.Path
+	}
+	return ""
+}
+
+func (x *ChrysalisTest) GetExpireAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ExpireAt
+	}
+	return nil
+}
+
+// Chain linking a source file to the tests depending on it
+// (chrysalis.Chain).
+type ChrysalisChain struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Path         string                 `protobuf:"bytes,1,opt,name=path,proto3" json:"path,omitempty"`
+	Checksum     string                 `protobuf:"bytes,2,opt,name=checksum,proto3" json:"checksum,omitempty"`
+	TestChecksum string                 `protobuf:"bytes,3,opt,name=test_checksum,json=testChecksum,proto3" json:"test_checksum,omitempty"`
+	Tests        []string               `protobuf:"bytes,4,rep,name=tests,proto3" json:"tests,omitempty"`
+	ExpireAt     *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=expire_at,json=expireAt,proto3" json:"expire_at,omitempty"` // unset if it never expires
+}
+
+func (x *ChrysalisChain) Reset() {
+	*x = ChrysalisChain{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_ti_v1_ti_proto_msgTypes[15]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ChrysalisChain) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ChrysalisChain) ProtoMessage() {}
+
+func (x *ChrysalisChain) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_ti_v1_ti_proto_msgTypes[15]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ChrysalisChain.ProtoReflect.Descriptor instead.
+func (*ChrysalisChain) Descriptor() ([]byte, []int) {
+	return file_proto_ti_v1_ti_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *ChrysalisChain) GetPath() string {
+	if x != nil {
+		return x.Path
+	}
+	return ""
+}
+
+func (x *ChrysalisChain) GetChecksum() string {
+	if x != nil {
+		return x.Checksum
+	}
+	return ""
+}
+
+func (x *ChrysalisChain) GetTestChecksum() string {
+	if x != nil {
+		return x.TestChecksum
+	}
+	return ""
+}
+
+func (x *ChrysalisChain) GetTests() []string {
+	if x != nil {
+		return x.Tests
+	}
+	return nil
+}
+
+func (x *ChrysalisChain) GetExpireAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ExpireAt
+	}
+	return nil
+}
+
+type TiConfig_Config struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Ignore                  []string `protobuf:"bytes,1,rep,name=ignore,proto3" json:"ignore,omitempty"`
+	EnableBazelOptimization bool     `protobuf:"varint,2,opt,name=enable_bazel_optimization,json=enableBazelOptimization,proto3" json:"enable_bazel_optimization,omitempty"`
+	BazelFileCountThreshold int32    `protobuf:"varint,3,opt,name=bazel_file_count_threshold,json=bazelFileCountThreshold,proto3" json:"bazel_file_count_threshold,omitempty"`
+	AlwaysRun               []string `protobuf:"bytes,4,rep,name=always_run,json=alwaysRun,proto3" json:"always_run,omitempty"` // tests which are never skipped
+}
+
+func (x *TiConfig_Config) Reset() {
+	*x = TiConfig_Config{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_ti_v1_ti_proto_msgTypes[16]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *TiConfig_Config) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TiConfig_Config) ProtoMessage() {}
+
+func (x *TiConfig_Config) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_ti_v1_ti_proto_msgTypes[16]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TiConfig_Config.ProtoReflect.Descriptor instead.
+func (*TiConfig_Config) Descriptor() ([]byte, []int) {
+	return file_proto_ti_v1_ti_proto_rawDescGZIP(), []int{4, 0}
+}
+
+func (x *TiConfig_Config) GetIgnore() []string {
+	if x != nil {
+		return x.Ignore
+	}
+	return nil
+}
+
+func (x *TiConfig_Config) GetEnableBazelOptimization() bool {
+	if x != nil {
+		return x.EnableBazelOptimization
+	}
+	return false
+}
+
+func (x *TiConfig_Config) GetBazelFileCountThreshold() int32 {
+	if x != nil {
+		return x.BazelFileCountThreshold
+	}
+	return 0
+}
+
+func (x *TiConfig_Config) GetAlwaysRun() []string {
+	if x != nil {
+		return x.AlwaysRun
+	}
+	return nil
+}
+
+type RunnableTest_Autodetect struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Rule string `protobuf:"bytes,1,opt,name=rule,proto3" json:"rule,omitempty"`
+}
+
+func (x *RunnableTest_Autodetect) Reset() {
+	*x = RunnableTest_Autodetect{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_ti_v1_ti_proto_msgTypes[17]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *RunnableTest_Autodetect) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RunnableTest_Autodetect) ProtoMessage() {}
+
+func (x *RunnableTest_Autodetect) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_ti_v1_ti_proto_msgTypes[17]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RunnableTest_Autodetect.ProtoReflect.Descriptor instead.
+func (*RunnableTest_Autodetect) Descriptor() ([]byte, []int) {
+	return file_proto_ti_v1_ti_proto_rawDescGZIP(), []int{9, 0}
+}
+
+func (x *RunnableTest_Autodetect) GetRule() string {
+	if x != nil {
+		return x.Rule
+	}
+	return ""
+}
+
+var File_proto_ti_v1_ti_proto protoreflect.FileDescriptor
+
+var file_proto_ti_v1_ti_proto_rawDesc = []byte{
+	0x0a, 0x14, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2f, 0x74, 0x69, 0x2f, 0x76, 0x31, 0x2f, 0x74, 0x69,
+	0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x0d, 0x68, 0x61, 0x72, 0x6e, 0x65, 0x73, 0x73, 0x2e,
+	0x74, 0x69, 0x2e, 0x76, 0x31, 0x1a, 0x1f, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2f, 0x70, 0x72,
+	0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2f, 0x74, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70,
+	0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0x62, 0x0a, 0x06, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74,
+	0x12, 0x16, 0x0a, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x18, 0x0a, 0x07, 0x6d, 0x65, 0x73, 0x73,
+	0x61, 0x67, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x6d, 0x65, 0x73, 0x73, 0x61,
+	0x67, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x74, 0x79, 0x70, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x04, 0x74, 0x79, 0x70, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x64, 0x65, 0x73, 0x63, 0x18, 0x04,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x64, 0x65, 0x73, 0x63, 0x22, 0xa5, 0x01, 0x0a, 0x0f, 0x50,
+	0x61, 0x72, 0x61, 0x6d, 0x65, 0x74, 0x65, 0x72, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x12, 0x12,
+	0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61,
+	0x6d, 0x65, 0x12, 0x2d, 0x0a, 0x06, 0x72, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x15, 0x2e, 0x68, 0x61, 0x72, 0x6e, 0x65, 0x73, 0x73, 0x2e, 0x74, 0x69, 0x2e,
+	0x76, 0x31, 0x2e, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x52, 0x06, 0x72, 0x65, 0x73, 0x75, 0x6c,
+	0x74, 0x12, 0x1f, 0x0a, 0x0b, 0x64, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x6d, 0x73,
+	0x18, 0x03, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0a, 0x64, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e,
+	0x4d, 0x73, 0x12, 0x16, 0x0a, 0x06, 0x73, 0x74, 0x64, 0x6f, 0x75, 0x74, 0x18, 0x04, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x06, 0x73, 0x74, 0x64, 0x6f, 0x75, 0x74, 0x12, 0x16, 0x0a, 0x06, 0x73, 0x74,
+	0x64, 0x65, 0x72, 0x72, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x73, 0x74, 0x64, 0x65,
+	0x72, 0x72, 0x22, 0xf3, 0x03, 0x0a, 0x08, 0x54, 0x65, 0x73, 0x74, 0x43, 0x61, 0x73, 0x65, 0x12,
+	0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e,
+	0x61, 0x6d, 0x65, 0x12, 0x1d, 0x0a, 0x0a, 0x63, 0x6c, 0x61, 0x73, 0x73, 0x5f, 0x6e, 0x61, 0x6d,
+	0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x63, 0x6c, 0x61, 0x73, 0x73, 0x4e, 0x61,
+	0x6d, 0x65, 0x12, 0x1b, 0x0a, 0x09, 0x66, 0x69, 0x6c, 0x65, 0x5f, 0x6e, 0x61, 0x6d, 0x65, 0x18,
+	0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x66, 0x69, 0x6c, 0x65, 0x4e, 0x61, 0x6d, 0x65, 0x12,
+	0x1d, 0x0a, 0x0a, 0x73, 0x75, 0x69, 0x74, 0x65, 0x5f, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x04, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x09, 0x73, 0x75, 0x69, 0x74, 0x65, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x2d,
+	0x0a, 0x06, 0x72, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x15,
+	0x2e, 0x68, 0x61, 0x72, 0x6e, 0x65, 0x73, 0x73, 0x2e, 0x74, 0x69, 0x2e, 0x76, 0x31, 0x2e, 0x52,
+	0x65, 0x73, 0x75, 0x6c, 0x74, 0x52, 0x06, 0x72, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x12, 0x1f, 0x0a,
+	0x0b, 0x64, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x6d, 0x73, 0x18, 0x06, 0x20, 0x01,
+	0x28, 0x03, 0x52, 0x0a, 0x64, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x4d, 0x73, 0x12, 0x16,
+	0x0a, 0x06, 0x73, 0x74, 0x64, 0x6f, 0x75, 0x74, 0x18, 0x07, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06,
+	0x73, 0x74, 0x64, 0x6f, 0x75, 0x74, 0x12, 0x16, 0x0a, 0x06, 0x73, 0x74, 0x64, 0x65, 0x72, 0x72,
+	0x18, 0x08, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x73, 0x74, 0x64, 0x65, 0x72, 0x72, 0x12, 0x14,
+	0x0a, 0x05, 0x6d, 0x75, 0x74, 0x65, 0x64, 0x18, 0x09, 0x20, 0x01, 0x28, 0x08, 0x52, 0x05, 0x6d,
+	0x75, 0x74, 0x65, 0x64, 0x12, 0x22, 0x0a, 0x0d, 0x73, 0x74, 0x61, 0x72, 0x74, 0x5f, 0x74, 0x69,
+	0x6d, 0x65, 0x5f, 0x6d, 0x73, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0b, 0x73, 0x74, 0x61,
+	0x72, 0x74, 0x54, 0x69, 0x6d, 0x65, 0x4d, 0x73, 0x12, 0x16, 0x0a, 0x06, 0x69, 0x73, 0x73, 0x75,
+	0x65, 0x73, 0x18, 0x0b, 0x20, 0x03, 0x28, 0x09, 0x52, 0x06, 0x69, 0x73, 0x73, 0x75, 0x65, 0x73,
+	0x12, 0x20, 0x0a, 0x0b, 0x71, 0x75, 0x61, 0x72, 0x61, 0x6e, 0x74, 0x69, 0x6e, 0x65, 0x64, 0x18,
+	0x0c, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0b, 0x71, 0x75, 0x61, 0x72, 0x61, 0x6e, 0x74, 0x69, 0x6e,
+	0x65, 0x64, 0x12, 0x3e, 0x0a, 0x0a, 0x70, 0x61, 0x72, 0x61, 0x6d, 0x65, 0x74, 0x65, 0x72, 0x73,
+	0x18, 0x0d, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1e, 0x2e, 0x68, 0x61, 0x72, 0x6e, 0x65, 0x73, 0x73,
+	0x2e, 0x74, 0x69, 0x2e, 0x76, 0x31, 0x2e, 0x50, 0x61, 0x72, 0x61, 0x6d, 0x65, 0x74, 0x65, 0x72,
+	0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x52, 0x0a, 0x70, 0x61, 0x72, 0x61, 0x6d, 0x65, 0x74, 0x65,
+	0x72, 0x73, 0x12, 0x1f, 0x0a, 0x0b, 0x73, 0x75, 0x69, 0x74, 0x65, 0x5f, 0x70, 0x68, 0x61, 0x73,
+	0x65, 0x18, 0x0e, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x73, 0x75, 0x69, 0x74, 0x65, 0x50, 0x68,
+	0x61, 0x73, 0x65, 0x12, 0x23, 0x0a, 0x0d, 0x73, 0x61, 0x6d, 0x70, 0x6c, 0x65, 0x5f, 0x77, 0x65,
+	0x69, 0x67, 0x68, 0x74, 0x18, 0x0f, 0x20, 0x01, 0x28, 0x01, 0x52, 0x0c, 0x73, 0x61, 0x6d, 0x70,
+	0x6c, 0x65, 0x57, 0x65, 0x69, 0x67, 0x68, 0x74, 0x22, 0x4c, 0x0a, 0x04, 0x46, 0x69, 0x6c, 0x65,
+	0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04,
+	0x6e, 0x61, 0x6d, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x18, 0x0a, 0x07,
+	0x70, 0x61, 0x63, 0x6b, 0x61, 0x67, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x70,
+	0x61, 0x63, 0x6b, 0x61, 0x67, 0x65, 0x22, 0xfd, 0x01, 0x0a, 0x08, 0x54, 0x69, 0x43, 0x6f, 0x6e,
+	0x66, 0x69, 0x67, 0x12, 0x36, 0x0a, 0x06, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x0b, 0x32, 0x1e, 0x2e, 0x68, 0x61, 0x72, 0x6e, 0x65, 0x73, 0x73, 0x2e, 0x74, 0x69,
+	0x2e, 0x76, 0x31, 0x2e, 0x54, 0x69, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x2e, 0x43, 0x6f, 0x6e,
+	0x66, 0x69, 0x67, 0x52, 0x06, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x1a, 0xb8, 0x01, 0x0a, 0x06,
+	0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x12, 0x16, 0x0a, 0x06, 0x69, 0x67, 0x6e, 0x6f, 0x72, 0x65,
+	0x18, 0x01, 0x20, 0x03, 0x28, 0x09, 0x52, 0x06, 0x69, 0x67, 0x6e, 0x6f, 0x72, 0x65, 0x12, 0x3a,
+	0x0a, 0x19, 0x65, 0x6e, 0x61, 0x62, 0x6c, 0x65, 0x5f, 0x62, 0x61, 0x7a, 0x65, 0x6c, 0x5f, 0x6f,
+	0x70, 0x74, 0x69, 0x6d, 0x69, 0x7a, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x08, 0x52, 0x17, 0x65, 0x6e, 0x61, 0x62, 0x6c, 0x65, 0x42, 0x61, 0x7a, 0x65, 0x6c, 0x4f, 0x70,
+	0x74, 0x69, 0x6d, 0x69, 0x7a, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x3b, 0x0a, 0x1a, 0x62, 0x61,
+	0x7a, 0x65, 0x6c, 0x5f, 0x66, 0x69, 0x6c, 0x65, 0x5f, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x5f, 0x74,
+	0x68, 0x72, 0x65, 0x73, 0x68, 0x6f, 0x6c, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x05, 0x52, 0x17,
+	0x62, 0x61, 0x7a, 0x65, 0x6c, 0x46, 0x69, 0x6c, 0x65, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x54, 0x68,
+	0x72, 0x65, 0x73, 0x68, 0x6f, 0x6c, 0x64, 0x12, 0x1d, 0x0a, 0x0a, 0x61, 0x6c, 0x77, 0x61, 0x79,
+	0x73, 0x5f, 0x72, 0x75, 0x6e, 0x18, 0x04, 0x20, 0x03, 0x28, 0x09, 0x52, 0x09, 0x61, 0x6c, 0x77,
+	0x61, 0x79, 0x73, 0x52, 0x75, 0x6e, 0x22, 0x84, 0x01, 0x0a, 0x10, 0x44, 0x65, 0x70, 0x65, 0x6e,
+	0x64, 0x65, 0x6e, 0x63, 0x79, 0x43, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x12, 0x1a, 0x0a, 0x08, 0x6c,
+	0x6f, 0x63, 0x6b, 0x66, 0x69, 0x6c, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x6c,
+	0x6f, 0x63, 0x6b, 0x66, 0x69, 0x6c, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x1f, 0x0a, 0x0b, 0x6f,
+	0x6c, 0x64, 0x5f, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x0a, 0x6f, 0x6c, 0x64, 0x56, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x1f, 0x0a, 0x0b,
+	0x6e, 0x65, 0x77, 0x5f, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x04, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x0a, 0x6e, 0x65, 0x77, 0x56, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x22, 0x7e, 0x0a,
+	0x0a, 0x50, 0x52, 0x4d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x12, 0x16, 0x0a, 0x06, 0x6e,
+	0x75, 0x6d, 0x62, 0x65, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x06, 0x6e, 0x75, 0x6d,
+	0x62, 0x65, 0x72, 0x12, 0x14, 0x0a, 0x05, 0x74, 0x69, 0x74, 0x6c, 0x65, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x05, 0x74, 0x69, 0x74, 0x6c, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x61, 0x75, 0x74,
+	0x68, 0x6f, 0x72, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x61, 0x75, 0x74, 0x68, 0x6f,
+	0x72, 0x12, 0x16, 0x0a, 0x06, 0x6c, 0x61, 0x62, 0x65, 0x6c, 0x73, 0x18, 0x04, 0x20, 0x03, 0x28,
+	0x09, 0x52, 0x06, 0x6c, 0x61, 0x62, 0x65, 0x6c, 0x73, 0x12, 0x12, 0x0a, 0x04, 0x6c, 0x69, 0x6e,
+	0x6b, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6c, 0x69, 0x6e, 0x6b, 0x22, 0xa8, 0x03,
+	0x0a, 0x0e, 0x53, 0x65, 0x6c, 0x65, 0x63, 0x74, 0x54, 0x65, 0x73, 0x74, 0x73, 0x52, 0x65, 0x71,
+	0x12, 0x1d, 0x0a, 0x0a, 0x73, 0x65, 0x6c, 0x65, 0x63, 0x74, 0x5f, 0x61, 0x6c, 0x6c, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x08, 0x52, 0x09, 0x73, 0x65, 0x6c, 0x65, 0x63, 0x74, 0x41, 0x6c, 0x6c, 0x12,
+	0x29, 0x0a, 0x05, 0x66, 0x69, 0x6c, 0x65, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x13,
+	0x2e, 0x68, 0x61, 0x72, 0x6e, 0x65, 0x73, 0x73, 0x2e, 0x74, 0x69, 0x2e, 0x76, 0x31, 0x2e, 0x46,
+	0x69, 0x6c, 0x65, 0x52, 0x05, 0x66, 0x69, 0x6c, 0x65, 0x73, 0x12, 0x23, 0x0a, 0x0d, 0x73, 0x6f,
+	0x75, 0x72, 0x63, 0x65, 0x5f, 0x62, 0x72, 0x61, 0x6e, 0x63, 0x68, 0x18, 0x03, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x0c, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x42, 0x72, 0x61, 0x6e, 0x63, 0x68, 0x12,
+	0x23, 0x0a, 0x0d, 0x74, 0x61, 0x72, 0x67, 0x65, 0x74, 0x5f, 0x62, 0x72, 0x61, 0x6e, 0x63, 0x68,
+	0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0c, 0x74, 0x61, 0x72, 0x67, 0x65, 0x74, 0x42, 0x72,
+	0x61, 0x6e, 0x63, 0x68, 0x12, 0x12, 0x0a, 0x04, 0x72, 0x65, 0x70, 0x6f, 0x18, 0x05, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x04, 0x72, 0x65, 0x70, 0x6f, 0x12, 0x34, 0x0a, 0x09, 0x74, 0x69, 0x5f, 0x63,
+	0x6f, 0x6e, 0x66, 0x69, 0x67, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x17, 0x2e, 0x68, 0x61,
+	0x72, 0x6e, 0x65, 0x73, 0x73, 0x2e, 0x74, 0x69, 0x2e, 0x76, 0x31, 0x2e, 0x54, 0x69, 0x43, 0x6f,
+	0x6e, 0x66, 0x69, 0x67, 0x52, 0x08, 0x74, 0x69, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x12, 0x1d,
+	0x0a, 0x0a, 0x74, 0x65, 0x73, 0x74, 0x5f, 0x67, 0x6c, 0x6f, 0x62, 0x73, 0x18, 0x07, 0x20, 0x03,
+	0x28, 0x09, 0x52, 0x09, 0x74, 0x65, 0x73, 0x74, 0x47, 0x6c, 0x6f, 0x62, 0x73, 0x12, 0x1a, 0x0a,
+	0x08, 0x6c, 0x61, 0x6e, 0x67, 0x75, 0x61, 0x67, 0x65, 0x18, 0x08, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x08, 0x6c, 0x61, 0x6e, 0x67, 0x75, 0x61, 0x67, 0x65, 0x12, 0x52, 0x0a, 0x14, 0x63, 0x68, 0x61,
+	0x6e, 0x67, 0x65, 0x64, 0x5f, 0x64, 0x65, 0x70, 0x65, 0x6e, 0x64, 0x65, 0x6e, 0x63, 0x69, 0x65,
+	0x73, 0x18, 0x09, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1f, 0x2e, 0x68, 0x61, 0x72, 0x6e, 0x65, 0x73,
+	0x73, 0x2e, 0x74, 0x69, 0x2e, 0x76, 0x31, 0x2e, 0x44, 0x65, 0x70, 0x65, 0x6e, 0x64, 0x65, 0x6e,
+	0x63, 0x79, 0x43, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x52, 0x13, 0x63, 0x68, 0x61, 0x6e, 0x67, 0x65,
+	0x64, 0x44, 0x65, 0x70, 0x65, 0x6e, 0x64, 0x65, 0x6e, 0x63, 0x69, 0x65, 0x73, 0x12, 0x29, 0x0a,
+	0x02, 0x70, 0x72, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x68, 0x61, 0x72, 0x6e,
+	0x65, 0x73, 0x73, 0x2e, 0x74, 0x69, 0x2e, 0x76, 0x31, 0x2e, 0x50, 0x52, 0x4d, 0x65, 0x74, 0x61,
+	0x64, 0x61, 0x74, 0x61, 0x52, 0x02, 0x70, 0x72, 0x22, 0x65, 0x0a, 0x0b, 0x54, 0x65, 0x73, 0x74,
+	0x48, 0x69, 0x73, 0x74, 0x6f, 0x72, 0x79, 0x12, 0x12, 0x0a, 0x04, 0x72, 0x75, 0x6e, 0x73, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x04, 0x72, 0x75, 0x6e, 0x73, 0x12, 0x16, 0x0a, 0x06, 0x70,
+	0x61, 0x73, 0x73, 0x65, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x06, 0x70, 0x61, 0x73,
+	0x73, 0x65, 0x64, 0x12, 0x16, 0x0a, 0x06, 0x66, 0x61, 0x69, 0x6c, 0x65, 0x64, 0x18, 0x03, 0x20,
+	0x01, 0x28, 0x05, 0x52, 0x06, 0x66, 0x61, 0x69, 0x6c, 0x65, 0x64, 0x12, 0x12, 0x0a, 0x04, 0x6c,
+	0x61, 0x73, 0x74, 0x18, 0x04, 0x20, 0x03, 0x28, 0x09, 0x52, 0x04, 0x6c, 0x61, 0x73, 0x74, 0x22,
+	0x87, 0x03, 0x0a, 0x0c, 0x52, 0x75, 0x6e, 0x6e, 0x61, 0x62, 0x6c, 0x65, 0x54, 0x65, 0x73, 0x74,
+	0x12, 0x10, 0x0a, 0x03, 0x70, 0x6b, 0x67, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x70,
+	0x6b, 0x67, 0x12, 0x14, 0x0a, 0x05, 0x63, 0x6c, 0x61, 0x73, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x05, 0x63, 0x6c, 0x61, 0x73, 0x73, 0x12, 0x16, 0x0a, 0x06, 0x6d, 0x65, 0x74, 0x68,
+	0x6f, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x6d, 0x65, 0x74, 0x68, 0x6f, 0x64,
+	0x12, 0x1c, 0x0a, 0x09, 0x73, 0x65, 0x6c, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x04, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x09, 0x73, 0x65, 0x6c, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x46,
+	0x0a, 0x0a, 0x61, 0x75, 0x74, 0x6f, 0x64, 0x65, 0x74, 0x65, 0x63, 0x74, 0x18, 0x05, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x26, 0x2e, 0x68, 0x61, 0x72, 0x6e, 0x65, 0x73, 0x73, 0x2e, 0x74, 0x69, 0x2e,
+	0x76, 0x31, 0x2e, 0x52, 0x75, 0x6e, 0x6e, 0x61, 0x62, 0x6c, 0x65, 0x54, 0x65, 0x73, 0x74, 0x2e,
+	0x41, 0x75, 0x74, 0x6f, 0x64, 0x65, 0x74, 0x65, 0x63, 0x74, 0x52, 0x0a, 0x61, 0x75, 0x74, 0x6f,
+	0x64, 0x65, 0x74, 0x65, 0x63, 0x74, 0x12, 0x23, 0x0a, 0x0a, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x64,
+	0x65, 0x6e, 0x63, 0x65, 0x18, 0x06, 0x20, 0x01, 0x28, 0x01, 0x48, 0x00, 0x52, 0x0a, 0x63, 0x6f,
+	0x6e, 0x66, 0x69, 0x64, 0x65, 0x6e, 0x63, 0x65, 0x88, 0x01, 0x01, 0x12, 0x14, 0x0a, 0x05, 0x73,
+	0x63, 0x6f, 0x72, 0x65, 0x18, 0x07, 0x20, 0x01, 0x28, 0x01, 0x52, 0x05, 0x73, 0x63, 0x6f, 0x72,
+	0x65, 0x12, 0x21, 0x0a, 0x09, 0x66, 0x6c, 0x61, 0x6b, 0x69, 0x6e, 0x65, 0x73, 0x73, 0x18, 0x08,
+	0x20, 0x01, 0x28, 0x01, 0x48, 0x01, 0x52, 0x09, 0x66, 0x6c, 0x61, 0x6b, 0x69, 0x6e, 0x65, 0x73,
+	0x73, 0x88, 0x01, 0x01, 0x12, 0x34, 0x0a, 0x07, 0x68, 0x69, 0x73, 0x74, 0x6f, 0x72, 0x79, 0x18,
+	0x09, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x68, 0x61, 0x72, 0x6e, 0x65, 0x73, 0x73, 0x2e,
+	0x74, 0x69, 0x2e, 0x76, 0x31, 0x2e, 0x54, 0x65, 0x73, 0x74, 0x48, 0x69, 0x73, 0x74, 0x6f, 0x72,
+	0x79, 0x52, 0x07, 0x68, 0x69, 0x73, 0x74, 0x6f, 0x72, 0x79, 0x1a, 0x20, 0x0a, 0x0a, 0x41, 0x75,
+	0x74, 0x6f, 0x64, 0x65, 0x74, 0x65, 0x63, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x72, 0x75, 0x6c, 0x65,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x72, 0x75, 0x6c, 0x65, 0x42, 0x0d, 0x0a, 0x0b,
+	0x5f, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x64, 0x65, 0x6e, 0x63, 0x65, 0x42, 0x0c, 0x0a, 0x0a, 0x5f,
+	0x66, 0x6c, 0x61, 0x6b, 0x69, 0x6e, 0x65, 0x73, 0x73, 0x22, 0xc7, 0x02, 0x0a, 0x0f, 0x53, 0x65,
+	0x6c, 0x65, 0x63, 0x74, 0x54, 0x65, 0x73, 0x74, 0x73, 0x52, 0x65, 0x73, 0x70, 0x12, 0x1f, 0x0a,
+	0x0b, 0x74, 0x6f, 0x74, 0x61, 0x6c, 0x5f, 0x74, 0x65, 0x73, 0x74, 0x73, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x05, 0x52, 0x0a, 0x74, 0x6f, 0x74, 0x61, 0x6c, 0x54, 0x65, 0x73, 0x74, 0x73, 0x12, 0x25,
+	0x0a, 0x0e, 0x73, 0x65, 0x6c, 0x65, 0x63, 0x74, 0x65, 0x64, 0x5f, 0x74, 0x65, 0x73, 0x74, 0x73,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0d, 0x73, 0x65, 0x6c, 0x65, 0x63, 0x74, 0x65, 0x64,
+	0x54, 0x65, 0x73, 0x74, 0x73, 0x12, 0x1b, 0x0a, 0x09, 0x6e, 0x65, 0x77, 0x5f, 0x74, 0x65, 0x73,
+	0x74, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x05, 0x52, 0x08, 0x6e, 0x65, 0x77, 0x54, 0x65, 0x73,
+	0x74, 0x73, 0x12, 0x23, 0x0a, 0x0d, 0x75, 0x70, 0x64, 0x61, 0x74, 0x65, 0x64, 0x5f, 0x74, 0x65,
+	0x73, 0x74, 0x73, 0x18, 0x04, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0c, 0x75, 0x70, 0x64, 0x61, 0x74,
+	0x65, 0x64, 0x54, 0x65, 0x73, 0x74, 0x73, 0x12, 0x24, 0x0a, 0x0e, 0x73, 0x72, 0x63, 0x5f, 0x63,
+	0x6f, 0x64, 0x65, 0x5f, 0x74, 0x65, 0x73, 0x74, 0x73, 0x18, 0x05, 0x20, 0x01, 0x28, 0x05, 0x52,
+	0x0c, 0x73, 0x72, 0x63, 0x43, 0x6f, 0x64, 0x65, 0x54, 0x65, 0x73, 0x74, 0x73, 0x12, 0x1d, 0x0a,
+	0x0a, 0x73, 0x65, 0x6c, 0x65, 0x63, 0x74, 0x5f, 0x61, 0x6c, 0x6c, 0x18, 0x06, 0x20, 0x01, 0x28,
+	0x08, 0x52, 0x09, 0x73, 0x65, 0x6c, 0x65, 0x63, 0x74, 0x41, 0x6c, 0x6c, 0x12, 0x31, 0x0a, 0x05,
+	0x74, 0x65, 0x73, 0x74, 0x73, 0x18, 0x07, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1b, 0x2e, 0x68, 0x61,
+	0x72, 0x6e, 0x65, 0x73, 0x73, 0x2e, 0x74, 0x69, 0x2e, 0x76, 0x31, 0x2e, 0x52, 0x75, 0x6e, 0x6e,
+	0x61, 0x62, 0x6c, 0x65, 0x54, 0x65, 0x73, 0x74, 0x52, 0x05, 0x74, 0x65, 0x73, 0x74, 0x73, 0x12,
+	0x23, 0x0a, 0x0a, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x64, 0x65, 0x6e, 0x63, 0x65, 0x18, 0x08, 0x20,
+	0x01, 0x28, 0x01, 0x48, 0x00, 0x52, 0x0a, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x64, 0x65, 0x6e, 0x63,
+	0x65, 0x88, 0x01, 0x01, 0x42, 0x0d, 0x0a, 0x0b, 0x5f, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x64, 0x65,
+	0x6e, 0x63, 0x65, 0x22, 0xa9, 0x01, 0x0a, 0x0d, 0x43, 0x61, 0x6c, 0x6c, 0x67, 0x72, 0x61, 0x70,
+	0x68, 0x4e, 0x6f, 0x64, 0x65, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x05, 0x52, 0x02, 0x69, 0x64, 0x12, 0x18, 0x0a, 0x07, 0x70, 0x61, 0x63, 0x6b, 0x61, 0x67, 0x65,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x70, 0x61, 0x63, 0x6b, 0x61, 0x67, 0x65, 0x12,
+	0x14, 0x0a, 0x05, 0x63, 0x6c, 0x61, 0x73, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05,
+	0x63, 0x6c, 0x61, 0x73, 0x73, 0x12, 0x12, 0x0a, 0x04, 0x66, 0x69, 0x6c, 0x65, 0x18, 0x04, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x04, 0x66, 0x69, 0x6c, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x74, 0x79, 0x70,
+	0x65, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x74, 0x79, 0x70, 0x65, 0x12, 0x12, 0x0a,
+	0x04, 0x72, 0x6f, 0x6f, 0x74, 0x18, 0x06, 0x20, 0x01, 0x28, 0x08, 0x52, 0x04, 0x72, 0x6f, 0x6f,
+	0x74, 0x12, 0x1c, 0x0a, 0x09, 0x69, 0x6d, 0x70, 0x6f, 0x72, 0x74, 0x61, 0x6e, 0x74, 0x18, 0x07,
+	0x20, 0x01, 0x28, 0x08, 0x52, 0x09, 0x69, 0x6d, 0x70, 0x6f, 0x72, 0x74, 0x61, 0x6e, 0x74, 0x22,
+	0x33, 0x0a, 0x0d, 0x43, 0x61, 0x6c, 0x6c, 0x67, 0x72, 0x61, 0x70, 0x68, 0x45, 0x64, 0x67, 0x65,
+	0x12, 0x12, 0x0a, 0x04, 0x66, 0x72, 0x6f, 0x6d, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x04,
+	0x66, 0x72, 0x6f, 0x6d, 0x12, 0x0e, 0x0a, 0x02, 0x74, 0x6f, 0x18, 0x02, 0x20, 0x03, 0x28, 0x05,
+	0x52, 0x02, 0x74, 0x6f, 0x22, 0x73, 0x0a, 0x09, 0x43, 0x61, 0x6c, 0x6c, 0x67, 0x72, 0x61, 0x70,
+	0x68, 0x12, 0x32, 0x0a, 0x05, 0x6e, 0x6f, 0x64, 0x65, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b,
+	0x32, 0x1c, 0x2e, 0x68, 0x61, 0x72, 0x6e, 0x65, 0x73, 0x73, 0x2e, 0x74, 0x69, 0x2e, 0x76, 0x31,
+	0x2e, 0x43, 0x61, 0x6c, 0x6c, 0x67, 0x72, 0x61, 0x70, 0x68, 0x4e, 0x6f, 0x64, 0x65, 0x52, 0x05,
+	0x6e, 0x6f, 0x64, 0x65, 0x73, 0x12, 0x32, 0x0a, 0x05, 0x65, 0x64, 0x67, 0x65, 0x73, 0x18, 0x02,
+	0x20, 0x03, 0x28, 0x0b, 0x32, 0x1c, 0x2e, 0x68, 0x61, 0x72, 0x6e, 0x65, 0x73, 0x73, 0x2e, 0x74,
+	0x69, 0x2e, 0x76, 0x31, 0x2e, 0x43, 0x61, 0x6c, 0x6c, 0x67, 0x72, 0x61, 0x70, 0x68, 0x45, 0x64,
+	0x67, 0x65, 0x52, 0x05, 0x65, 0x64, 0x67, 0x65, 0x73, 0x22, 0x6e, 0x0a, 0x0d, 0x43, 0x68, 0x72,
+	0x79, 0x73, 0x61, 0x6c, 0x69, 0x73, 0x54, 0x65, 0x73, 0x74, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65,
+	0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x12, 0x0a, 0x04,
+	0x70, 0x61, 0x74, 0x68, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x70, 0x61, 0x74, 0x68,
+	0x12, 0x37, 0x0a, 0x09, 0x65, 0x78, 0x70, 0x69, 0x72, 0x65, 0x5f, 0x61, 0x74, 0x18, 0x03, 0x20,
+	0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52,
+	0x08, 0x65, 0x78, 0x70, 0x69, 0x72, 0x65, 0x41, 0x74, 0x22, 0xb4, 0x01, 0x0a, 0x0e, 0x43, 0x68,
+	0x72, 0x79, 0x73, 0x61, 0x6c, 0x69, 0x73, 0x43, 0x68, 0x61, 0x69, 0x6e, 0x12, 0x12, 0x0a, 0x04,
+	0x70, 0x61, 0x74, 0x68, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x70, 0x61, 0x74, 0x68,
+	0x12, 0x1a, 0x0a, 0x08, 0x63, 0x68, 0x65, 0x63, 0x6b, 0x73, 0x75, 0x6d, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x08, 0x63, 0x68, 0x65, 0x63, 0x6b, 0x73, 0x75, 0x6d, 0x12, 0x23, 0x0a, 0x0d,
+	0x74, 0x65, 0x73, 0x74, 0x5f, 0x63, 0x68, 0x65, 0x63, 0x6b, 0x73, 0x75, 0x6d, 0x18, 0x03, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x0c, 0x74, 0x65, 0x73, 0x74, 0x43, 0x68, 0x65, 0x63, 0x6b, 0x73, 0x75,
+	0x6d, 0x12, 0x14, 0x0a, 0x05, 0x74, 0x65, 0x73, 0x74, 0x73, 0x18, 0x04, 0x20, 0x03, 0x28, 0x09,
+	0x52, 0x05, 0x74, 0x65, 0x73, 0x74, 0x73, 0x12, 0x37, 0x0a, 0x09, 0x65, 0x78, 0x70, 0x69, 0x72,
+	0x65, 0x5f, 0x61, 0x74, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f,
+	0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d,
+	0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x08, 0x65, 0x78, 0x70, 0x69, 0x72, 0x65, 0x41, 0x74,
+	0x42, 0x43, 0x0a, 0x10, 0x69, 0x6f, 0x2e, 0x68, 0x61, 0x72, 0x6e, 0x65, 0x73, 0x73, 0x2e, 0x74,
+	0x69, 0x2e, 0x76, 0x31, 0x50, 0x01, 0x5a, 0x2d, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63,
+	0x6f, 0x6d, 0x2f, 0x68, 0x61, 0x72, 0x6e, 0x65, 0x73, 0x73, 0x2f, 0x74, 0x69, 0x2d, 0x63, 0x6c,
+	0x69, 0x65, 0x6e, 0x74, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2f, 0x74, 0x69, 0x2f, 0x76, 0x31,
+	0x3b, 0x74, 0x69, 0x76, 0x31, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+}
+
+var (
+	file_proto_ti_v1_ti_proto_rawDescOnce sync.Once
+	file_proto_ti_v1_ti_proto_rawDescData = file_proto_ti_v1_ti_proto_rawDesc
+)
+
+func file_proto_ti_v1_ti_proto_rawDescGZIP() []byte {
+	file_proto_ti_v1_ti_proto_rawDescOnce.Do(func() {
+		file_proto_ti_v1_ti_proto_rawDescData = protoimpl.X.CompressGZIP(file_proto_ti_v1_ti_proto_rawDescData)
+	})
+	return file_proto_ti_v1_ti_proto_rawDescData
+}
+
+var file_proto_ti_v1_ti_proto_msgTypes = make([]protoimpl.MessageInfo, 18)
+var file_proto_ti_v1_ti_proto_goTypes = []interface{}{
+	(*Result)(nil),                  // 0: harness.ti.v1.Result
+	(*ParameterResult)(nil),         // 1: harness.ti.v1.ParameterResult
+	(*TestCase)(nil),                // 2: harness.ti.v1.TestCase
+	(*File)(nil),                    // 3: harness.ti.v1.File
+	(*TiConfig)(nil),                // 4: harness.ti.v1.TiConfig
+	(*DependencyChange)(nil),        // 5: harness.ti.v1.DependencyChange
+	(*PRMetadata)(nil),              // 6: harness.ti.v1.PRMetadata
+	(*SelectTestsReq)(nil),          // 7: harness.ti.v1.SelectTestsReq
+	(*TestHistory)(nil),             // 8: harness.ti.v1.TestHistory
+	(*RunnableTest)(nil),            // 9: harness.ti.v1.RunnableTest
+	(*SelectTestsResp)(nil),         // 10: harness.ti.v1.SelectTestsResp
+	(*CallgraphNode)(nil),           // 11: harness.ti.v1.CallgraphNode
+	(*CallgraphEdge)(nil),           // 12: harness.ti.v1.CallgraphEdge
+	(*Callgraph)(nil),               // 13: harness.ti.v1.Callgraph
+	(*ChrysalisTest)(nil),           // 14: harness.ti.v1.ChrysalisTest
+	(*ChrysalisChain)(nil),          // 15: harness.ti.v1.ChrysalisChain
+	(*TiConfig_Config)(nil),         // 16: harness.ti.v1.TiConfig.Config
+	(*RunnableTest_Autodetect)(nil), // 17: harness.ti.v1.RunnableTest.Autodetect
+	(*timestamppb.Timestamp)(nil),   // 18: google.protobuf.Timestamp
+}
+var file_proto_ti_v1_ti_proto_depIdxs = []int32{
+	0,  // 0: harness.ti.v1.ParameterResult.result:type_name -> harness.ti.v1.Result
+	0,  // 1: harness.ti.v1.TestCase.result:type_name -> harness.ti.v1.Result
+	1,  // 2: harness.ti.v1.TestCase.parameters:type_name -> harness.ti.v1.ParameterResult
+	16, // 3: harness.ti.v1.TiConfig.config:type_name -> harness.ti.v1.TiConfig.Config
+	3,  // 4: harness.ti.v1.SelectTestsReq.files:type_name -> harness.ti.v1.File
+	4,  // 5: harness.ti.v1.SelectTestsReq.ti_config:type_name -> harness.ti.v1.TiConfig
+	5,  // 6: harness.ti.v1.SelectTestsReq.changed_dependencies:type_name -> harness.ti.v1.DependencyChange
+	6,  // 7: harness.ti.v1.SelectTestsReq.pr:type_name -> harness.ti.v1.PRMetadata
+	17, // 8: harness.ti.v1.RunnableTest.autodetect:type_name -> harness.ti.v1.RunnableTest.Autodetect
+	8,  // 9: harness.ti.v1.RunnableTest.history:type_name -> harness.ti.v1.TestHistory
+	9,  // 10: harness.ti.v1.SelectTestsResp.tests:type_name -> harness.ti.v1.RunnableTest
+	11, // 11: harness.ti.v1.Callgraph.nodes:type_name -> harness.ti.v1.CallgraphNode
+	12, // 12: harness.ti.v1.Callgraph.edges:type_name -> harness.ti.v1.CallgraphEdge
+	18, // 13: harness.ti.v1.ChrysalisTest.expire_at:type_name -> google.protobuf.Timestamp
+	18, // 14: harness.ti.v1.ChrysalisChain.expire_at:type_name -> google.protobuf.Timestamp
+	15, // [15:15] is the sub-list for method output_type
+	15, // [15:15] is the sub-list for method input_type
+	15, // [15:15] is the sub-list for extension type_name
+	15, // [15:15] is the sub-list for extension extendee
+	0,  // [0:15] is the sub-list for field type_name
+}
+
+func init() { file_proto_ti_v1_ti_proto_init() }
+func file_proto_ti_v1_ti_proto_init() {
+	if File_proto_ti_v1_ti_proto != nil {
+		return
+	}
+	if !protoimpl.UnsafeEnabled {
+		file_proto_ti_v1_ti_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Result); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_ti_v1_ti_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ParameterResult); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_ti_v1_ti_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*TestCase); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_ti_v1_ti_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*File); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_ti_v1_ti_proto_msgTypes[4].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*TiConfig); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_ti_v1_ti_proto_msgTypes[5].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*DependencyChange); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_ti_v1_ti_proto_msgTypes[6].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*PRMetadata); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_ti_v1_ti_proto_msgTypes[7].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SelectTestsReq); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_ti_v1_ti_proto_msgTypes[8].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*TestHistory); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_ti_v1_ti_proto_msgTypes[9].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*RunnableTest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_ti_v1_ti_proto_msgTypes[10].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SelectTestsResp); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_ti_v1_ti_proto_msgTypes[11].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CallgraphNode); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_ti_v1_ti_proto_msgTypes[12].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CallgraphEdge); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_ti_v1_ti_proto_msgTypes[13].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Callgraph); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_ti_v1_ti_proto_msgTypes[14].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ChrysalisTest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_ti_v1_ti_proto_msgTypes[15].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ChrysalisChain); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_ti_v1_ti_proto_msgTypes[16].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*TiConfig_Config); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_ti_v1_ti_proto_msgTypes[17].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*RunnableTest_Autodetect); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+	}
+	file_proto_ti_v1_ti_proto_msgTypes[9].OneofWrappers = []interface{}{}
+	file_proto_ti_v1_ti_proto_msgTypes[10].OneofWrappers = []interface{}{}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_proto_ti_v1_ti_proto_rawDesc,
+			NumEnums:      0,
+			NumMessages:   18,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_proto_ti_v1_ti_proto_goTypes,
+		DependencyIndexes: file_proto_ti_v1_ti_proto_depIdxs,
+		MessageInfos:      file_proto_ti_v1_ti_proto_msgTypes,
+	}.Build()
+	File_proto_ti_v1_ti_proto = out.File
+	file_proto_ti_v1_ti_proto_rawDesc = nil
+	file_proto_ti_v1_ti_proto_goTypes = nil
+	file_proto_ti_v1_ti_proto_depIdxs = nil
+}
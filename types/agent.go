@@ -0,0 +1,22 @@
+package types
+
+type AgentCompatibilityReq struct {
+	Language string `json:"language"`
+	Version  string `json:"version"`
+	// ServerVersion is the TI server version to check against. The version
+	// of the server answering the request is used if it is empty.
+	ServerVersion string `json:"server_version,omitempty"`
+}
+
+// AgentCompatibility reports whether an agent version can be used with a TI
+// server version.
+type AgentCompatibility struct {
+	Compatible bool `json:"compatible"`
+	// Deprecated is set when the agent version still works but support for
+	// it will be removed.
+	Deprecated         bool     `json:"deprecated"`
+	MinVersion         string   `json:"min_version"`
+	RecommendedVersion string   `json:"recommended_version"`
+	ServerVersion      string   `json:"server_version"`
+	Warnings           []string `json:"warnings"`
+}
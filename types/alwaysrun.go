@@ -0,0 +1,33 @@
+package types
+
+import "strings"
+
+// ParseTestRefs converts entries of the form "[<pkg>.]<class>[#<method>]"
+// to runnable tests. An entry without a method stands for its whole class.
+func ParseTestRefs(entries []string) []RunnableTest {
+	tests := make([]RunnableTest, 0, len(entries))
+	for _, e := range entries {
+		e = strings.TrimSpace(e)
+		if e == "" {
+			continue
+		}
+		var t RunnableTest
+		class, method, _ := strings.Cut(e, "#")
+		t.Method = method
+		if i := strings.LastIndex(class, "."); i >= 0 {
+			t.Pkg, t.Class = class[:i], class[i+1:]
+		} else {
+			t.Class = class
+		}
+		tests = append(tests, t)
+	}
+	return tests
+}
+
+// AddAlwaysRun adds the tests which must never be skipped to the selection,
+// with the SelectAlwaysRunTest reason. Tests which are already selected
+// keep their selection reason. Nothing changes when all the tests are
+// selected.
+func (r *SelectTestsResp) AddAlwaysRun(tests []RunnableTest) {
+	r.addSelected(tests, SelectAlwaysRunTest)
+}
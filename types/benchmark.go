@@ -0,0 +1,16 @@
+package types
+
+// BenchmarkResult is a metric measured by a performance test.
+type BenchmarkResult struct {
+	Name      string `json:"name"`
+	SuiteName string `json:"suite_name"`
+	// Metric is the name of the measured metric, e.g. "ns/op" or "p99_latency".
+	Metric string  `json:"metric"`
+	Value  float64 `json:"value"`
+	Unit   string  `json:"unit"`
+	// Baseline is the value of the metric on the baseline build, if known.
+	Baseline *float64 `json:"baseline,omitempty"`
+	// Regression is set when the value regressed past the configured
+	// tolerance relative to the baseline.
+	Regression bool `json:"regression"`
+}
@@ -0,0 +1,43 @@
+// Package chrysalis contains the documents of chrysalis, the test
+// intelligence engine relating the source files of a repository to the
+// tests which depend on them.
+package chrysalis
+
+import "time"
+
+// Test is a test known to chrysalis.
+type Test struct {
+	// Key identifies the test, eg by its canonical identity.
+	Key string `json:"key"`
+	// Path is the slash separated path of the file defining the test,
+	// relative to the workspace root.
+	Path string `json:"path"`
+	// ExpireAt is nil for tests which never expire.
+	ExpireAt *time.Time `json:"expire_at,omitempty"`
+}
+
+// Chain links a source file to the tests which depend on it.
+type Chain struct {
+	// Path is the slash separated path of the source file, relative to
+	// the workspace root.
+	Path string `json:"path"`
+	// Checksum is the checksum of the source file.
+	Checksum string `json:"checksum"`
+	// TestChecksum is the checksum of the tests depending on the file, so
+	// that a changed set of tests invalidates the chain.
+	TestChecksum string `json:"test_checksum"`
+	// Tests are the keys of the tests depending on the file.
+	Tests []string `json:"tests,omitempty"`
+	// ExpireAt is nil for chains which never expire.
+	ExpireAt *time.Time `json:"expire_at,omitempty"`
+}
+
+// Expired reports whether the test expired at the given time.
+func (t Test) Expired(now time.Time) bool {
+	return t.ExpireAt != nil && !now.Before(*t.ExpireAt)
+}
+
+// Expired reports whether the chain expired at the given time.
+func (c Chain) Expired(now time.Time) bool {
+	return c.ExpireAt != nil && !now.Before(*c.ExpireAt)
+}
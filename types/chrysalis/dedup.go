@@ -0,0 +1,37 @@
+package chrysalis
+
+// DedupTests returns tests without the repeated occurrences of a key and
+// path, keeping the first one, along with the number of tests dropped.
+// Agents occasionally report a test twice. tests is not modified.
+func DedupTests(tests []Test) ([]Test, int) {
+	type key struct{ key, path string }
+	seen := make(map[key]bool, len(tests))
+	out := make([]Test, 0, len(tests))
+	for _, t := range tests {
+		k := key{t.Key, t.Path}
+		if seen[k] {
+			continue
+		}
+		seen[k] = true
+		out = append(out, t)
+	}
+	return out, len(tests) - len(out)
+}
+
+// DedupChains returns chains without the repeated occurrences of a path,
+// checksum and test checksum, keeping the first one, along with the number
+// of chains dropped. chains is not modified.
+func DedupChains(chains []Chain) ([]Chain, int) {
+	type key struct{ path, checksum, testChecksum string }
+	seen := make(map[key]bool, len(chains))
+	out := make([]Chain, 0, len(chains))
+	for _, c := range chains {
+		k := key{c.Path, c.Checksum, c.TestChecksum}
+		if seen[k] {
+			continue
+		}
+		seen[k] = true
+		out = append(out, c)
+	}
+	return out, len(chains) - len(out)
+}
@@ -0,0 +1,47 @@
+package types
+
+// fillDefault sets *v to d if it is empty.
+func fillDefault(v *string, d string) {
+	if *v == "" {
+		*v = d
+	}
+}
+
+// WithDefaults returns a copy of b with the identifiers it is missing taken
+// from defaults. The stage and step are never defaulted.
+func (b BasicInfo) WithDefaults(defaults BasicInfo) BasicInfo {
+	fillDefault(&b.OrgID, defaults.OrgID)
+	fillDefault(&b.ProjectID, defaults.ProjectID)
+	fillDefault(&b.PipelineID, defaults.PipelineID)
+	fillDefault(&b.BuildID, defaults.BuildID)
+	fillDefault(&b.ParentPipelineID, defaults.ParentPipelineID)
+	fillDefault(&b.ParentBuildID, defaults.ParentBuildID)
+	return b
+}
+
+// WithDefaults returns a copy of r with the missing identifiers taken from
+// defaults, as by BasicInfo.WithDefaults, and the report type defaulting to
+// JUnit. The stage and step are cleared when AllStages is set.
+func (r SummaryRequest) WithDefaults(defaults BasicInfo) SummaryRequest {
+	fillDefault(&r.OrgID, defaults.OrgID)
+	fillDefault(&r.ProjectID, defaults.ProjectID)
+	fillDefault(&r.PipelineID, defaults.PipelineID)
+	fillDefault(&r.BuildID, defaults.BuildID)
+	fillDefault(&r.ParentPipelineID, defaults.ParentPipelineID)
+	fillDefault(&r.ParentBuildID, defaults.ParentBuildID)
+	if r.ReportType == "" {
+		r.ReportType = ReportJUnit
+	}
+	if r.AllStages {
+		r.StageID = ""
+		r.StepID = ""
+	}
+	return r
+}
+
+// WithDefaults returns a copy of r with its BasicInfo filled as by
+// SummaryRequest.WithDefaults.
+func (r TestCasesRequest) WithDefaults(defaults BasicInfo) TestCasesRequest {
+	r.BasicInfo = r.BasicInfo.WithDefaults(defaults)
+	return r
+}
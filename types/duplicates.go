@@ -0,0 +1,72 @@
+package types
+
+import "sort"
+
+// DuplicateTest is a test executed by more than one step of a stage.
+type DuplicateTest struct {
+	ClassName string   `json:"class_name"`
+	Name      string   `json:"name"`
+	Steps     []string `json:"steps"`
+	// WastedMs is the time spent running the test in all the steps but the
+	// one where it took the longest.
+	WastedMs int64 `json:"wasted_ms"`
+}
+
+// DuplicationReport lists the tests executed more than once in a stage.
+type DuplicationReport struct {
+	Tests    []DuplicateTest `json:"tests"`
+	WastedMs int64           `json:"wasted_ms"`
+}
+
+// FindDuplicateTests returns the tests executed by more than one step,
+// given the test results of the steps of a stage keyed by step ID. Tests
+// are identified by their canonical class and name; skipped tests don't
+// count as executed. Repeated runs of a test within a step (eg retries of flaky tests) are not
+// duplication and only add to the time of that step.
+func FindDuplicateTests(results map[string][]*TestCase) DuplicationReport {
+	type key struct{ class, name string }
+	byTest := make(map[key]map[string]int64)
+	for step, tests := range results {
+		for _, t := range tests {
+			if t.Result.Status == StatusSkipped {
+				continue
+			}
+			k := key{CanonicalClass(t.ClassName), CanonicalMethod(t.Name)}
+			if byTest[k] == nil {
+				byTest[k] = make(map[string]int64)
+			}
+			byTest[k][step] += t.DurationMs
+		}
+	}
+
+	var report DuplicationReport
+	for k, steps := range byTest {
+		if len(steps) < 2 {
+			continue
+		}
+		d := DuplicateTest{ClassName: k.class, Name: k.name}
+		var total, longest int64
+		for step, ms := range steps {
+			d.Steps = append(d.Steps, step)
+			total += ms
+			if ms > longest {
+				longest = ms
+			}
+		}
+		sort.Strings(d.Steps)
+		d.WastedMs = total - longest
+		report.WastedMs += d.WastedMs
+		report.Tests = append(report.Tests, d)
+	}
+	sort.Slice(report.Tests, func(i, j int) bool {
+		a, b := report.Tests[i], report.Tests[j]
+		if a.WastedMs != b.WastedMs {
+			return a.WastedMs > b.WastedMs
+		}
+		if a.ClassName != b.ClassName {
+			return a.ClassName < b.ClassName
+		}
+		return a.Name < b.Name
+	})
+	return report
+}
@@ -0,0 +1,79 @@
+package types
+
+import (
+	"fmt"
+	"strings"
+)
+
+// ReportType is the format of a test report.
+type ReportType string
+
+// SortField is a test case field results can be sorted by.
+type SortField string
+
+// SortOrder is the direction of a sort.
+type SortOrder string
+
+const (
+	// ReportJUnit represents JUnit XML reports.
+	ReportJUnit ReportType = "junit"
+
+	SortName       SortField = "name"
+	SortClassName  SortField = "class_name"
+	SortSuiteName  SortField = "suite_name"
+	SortStatus     SortField = "status"
+	SortDurationMs SortField = "duration_ms"
+
+	OrderAsc  SortOrder = "ASC"
+	OrderDesc SortOrder = "DESC"
+)
+
+var (
+	reportTypes = []ReportType{ReportJUnit}
+	sortFields  = []SortField{SortName, SortClassName, SortSuiteName, SortStatus, SortDurationMs}
+	sortOrders  = []SortOrder{OrderAsc, OrderDesc}
+)
+
+// Validate returns an error if r is not a known report type.
+func (r ReportType) Validate() error {
+	for _, v := range reportTypes {
+		if r == v {
+			return nil
+		}
+	}
+	return fmt.Errorf("unknown report type %q, expected one of %s", string(r), join(reportTypes))
+}
+
+// Validate returns an error if s is neither empty nor a known sort field.
+func (s SortField) Validate() error {
+	if s == "" {
+		return nil
+	}
+	for _, v := range sortFields {
+		if s == v {
+			return nil
+		}
+	}
+	return fmt.Errorf("unknown sort field %q, expected one of %s", string(s), join(sortFields))
+}
+
+// Validate returns an error if o is neither empty nor a known sort order.
+func (o SortOrder) Validate() error {
+	if o == "" {
+		return nil
+	}
+	for _, v := range sortOrders {
+		if o == v {
+			return nil
+		}
+	}
+	return fmt.Errorf("unknown sort order %q, expected one of %s", string(o), join(sortOrders))
+}
+
+func join[T ~string](values []T) string {
+	s := make([]string, len(values))
+	for i, v := range values {
+		s[i] = string(v)
+	}
+	return strings.Join(s, ", ")
+}
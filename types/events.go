@@ -0,0 +1,83 @@
+package types
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+// EventType is the type of a TI event.
+type EventType string
+
+const (
+	EventCallgraphProcessed EventType = "callgraph_processed"
+	EventSelectionReady     EventType = "selection_ready"
+	EventFlakyTestDetected  EventType = "flaky_test_detected"
+)
+
+// Event is a TI event delivered to webhooks or streamed by the server.
+// Payload holds one of the *Event payload types, depending on Type.
+type Event struct {
+	ID         string          `json:"id"`
+	Type       EventType       `json:"type"`
+	Time       time.Time       `json:"time"`
+	AccountID  string          `json:"account_id"`
+	OrgID      string          `json:"org_id"`
+	ProjectID  string          `json:"project_id"`
+	PipelineID string          `json:"pipeline_id,omitempty"`
+	BuildID    string          `json:"build_id,omitempty"`
+	Payload    json.RawMessage `json:"payload"`
+}
+
+// CallgraphProcessedEvent is the payload of EventCallgraphProcessed.
+type CallgraphProcessedEvent struct {
+	Repo   string `json:"repo"`
+	Sha    string `json:"sha"`
+	Branch string `json:"branch"`
+	StepID string `json:"step_id"`
+}
+
+// SelectionReadyEvent is the payload of EventSelectionReady.
+type SelectionReadyEvent struct {
+	StepID    string          `json:"step_id"`
+	Selection SelectTestsResp `json:"selection"`
+}
+
+// FlakyTestDetectedEvent is the payload of EventFlakyTestDetected.
+type FlakyTestDetectedEvent struct {
+	Repo      string `json:"repo"`
+	ClassName string `json:"class_name"`
+	Name      string `json:"name"`
+	// FlakeRate is the fraction (0-1) of recent runs with inconsistent results.
+	FlakeRate float64 `json:"flake_rate"`
+}
+
+// Decode returns the typed payload of the event: a *CallgraphProcessedEvent,
+// *SelectionReadyEvent or *FlakyTestDetectedEvent.
+func (e Event) Decode() (interface{}, error) {
+	var v interface{}
+	switch e.Type {
+	case EventCallgraphProcessed:
+		v = &CallgraphProcessedEvent{}
+	case EventSelectionReady:
+		v = &SelectionReadyEvent{}
+	case EventFlakyTestDetected:
+		v = &FlakyTestDetectedEvent{}
+	default:
+		return nil, fmt.Errorf("unknown event type %q", string(e.Type))
+	}
+	if err := json.Unmarshal(e.Payload, v); err != nil {
+		return nil, err
+	}
+	return v, nil
+}
+
+// Webhook is an endpoint the TI service delivers events to.
+type Webhook struct {
+	ID     string      `json:"id,omitempty"`
+	URL    string      `json:"url"`
+	Events []EventType `json:"events"`
+	// Secret signs deliveries, see client.VerifyWebhookSignature. It is only
+	// returned when the webhook is created.
+	Secret string `json:"secret,omitempty"`
+}
@@ -0,0 +1,59 @@
+package types
+
+import "sort"
+
+// SelectPreviousFailure represents a selection of a test because it failed
+// in a previous run.
+const SelectPreviousFailure = "previous_failure"
+
+// FailedTestPaths returns the sorted, de-duplicated file paths of the tests
+// which truly failed or errored, ie not muted nor quarantined. Tests without a file name are identified by
+// their class name.
+func FailedTestPaths(tests []*TestCase) []string {
+	seen := make(map[string]bool)
+	var paths []string
+	for _, t := range tests {
+		if !t.IsTrueFailure() {
+			continue
+		}
+		p := t.FileName
+		if p == "" {
+			p = t.ClassName
+		}
+		if p == "" || seen[p] {
+			continue
+		}
+		seen[p] = true
+		paths = append(paths, p)
+	}
+	sort.Strings(paths)
+	return paths
+}
+
+// AddPreviousFailures adds the previously failed tests to the selection so
+// that they are rerun. Tests which are already selected keep their
+// selection reason. Nothing changes when all the tests are selected.
+func (r *SelectTestsResp) AddPreviousFailures(failed []RunnableTest) {
+	r.addSelected(failed, SelectPreviousFailure)
+}
+
+// addSelected adds the tests which are not selected yet with the given
+// selection reason.
+func (r *SelectTestsResp) addSelected(tests []RunnableTest, reason Selection) {
+	if r.SelectAll {
+		return
+	}
+	selected := make(map[string]bool, len(r.Tests))
+	for _, t := range r.Tests {
+		selected[t.CanonicalIdentity()] = true
+	}
+	for _, t := range tests {
+		if selected[t.CanonicalIdentity()] {
+			continue
+		}
+		selected[t.CanonicalIdentity()] = true
+		t.Selection = reason
+		r.Tests = append(r.Tests, t)
+		r.SelectedTests++
+	}
+}
@@ -0,0 +1,25 @@
+package types
+
+// TestHistory summarizes the last results of a test.
+type TestHistory struct {
+	Runs   int `json:"runs"`
+	Passed int `json:"passed"`
+	Failed int `json:"failed"`
+	// Last holds the statuses of the last runs, most recent first.
+	Last []Status `json:"last,omitempty"`
+}
+
+// IsFlaky reports whether the flakiness score of the test reaches
+// threshold. Tests without a score are considered stable.
+func (t RunnableTest) IsFlaky(threshold float64) bool {
+	return t.Flakiness != nil && *t.Flakiness >= threshold
+}
+
+// RetryBudget returns the number of retries to allow the test: maxRetries
+// for flaky tests and none for stable ones, so that they fail fast.
+func (t RunnableTest) RetryBudget(maxRetries int, threshold float64) int {
+	if t.IsFlaky(threshold) {
+		return maxRetries
+	}
+	return 0
+}
@@ -0,0 +1,47 @@
+package types
+
+type GapSeverity string
+
+const (
+	// GapSeverityLow represents untested code with little impact, eg a new
+	// private helper.
+	GapSeverityLow GapSeverity = "low"
+
+	// GapSeverityMedium represents untested changes to existing code.
+	GapSeverityMedium GapSeverity = "medium"
+
+	// GapSeverityHigh represents untested changes to code which is widely
+	// used according to the callgraph.
+	GapSeverityHigh GapSeverity = "high"
+)
+
+// TestGap is a changed source file or method not covered by any test
+// according to the callgraph.
+type TestGap struct {
+	File     string      `json:"file"`
+	Package  string      `json:"package"`
+	Class    string      `json:"class"`
+	Method   string      `json:"method"` // empty if the whole file is uncovered
+	Severity GapSeverity `json:"severity"`
+	Reason   string      `json:"reason"`
+}
+
+type TestGapsReq struct {
+	Files        []File `json:"files"`
+	SourceBranch string `json:"source_branch"`
+	TargetBranch string `json:"target_branch"`
+}
+
+type TestGapsResp struct {
+	Gaps []TestGap `json:"gaps"`
+}
+
+// HasSeverity reports whether any gap is of the given severity.
+func (r TestGapsResp) HasSeverity(severity GapSeverity) bool {
+	for _, g := range r.Gaps {
+		if g.Severity == severity {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,85 @@
+package types
+
+import "strings"
+
+// CanonicalClass normalizes a class name so that the names reported by
+// different runners and parsers for the same class compare equal: nested
+// class separators ($) become dots and generic type parameters are
+// stripped, eg "com.acme.Outer$Inner<T>" becomes "com.acme.Outer.Inner".
+func CanonicalClass(class string) string {
+	class = stripGenerics(strings.TrimSpace(class))
+	return strings.ReplaceAll(class, "$", ".")
+}
+
+// CanonicalMethod normalizes a test method name by stripping the
+// parameterization suffixes added by runners, such as the invocation index
+// "[0]", the pytest parameter id "[a-b]" or the signature "(int, String)",
+// eg "testParse(String)[2]" becomes "testParse".
+func CanonicalMethod(method string) string {
+	method = strings.TrimSpace(method)
+	for {
+		trimmed := strings.TrimSpace(stripSuffix(stripSuffix(method, '[', ']'), '(', ')'))
+		if trimmed == method || trimmed == "" {
+			return method
+		}
+		method = trimmed
+	}
+}
+
+// CanonicalIdentity returns the identity of a test from its class and
+// method names, to be used as key whenever tests from different sources
+// (results, selections, history) are matched.
+func CanonicalIdentity(class, method string) string {
+	return CanonicalClass(class) + "#" + CanonicalMethod(method)
+}
+
+// CanonicalIdentity returns the canonical identity of the test.
+func (t RunnableTest) CanonicalIdentity() string {
+	return CanonicalIdentity(qualifiedClass(t), t.Method)
+}
+
+// CanonicalIdentity returns the canonical identity of the test.
+func (t *TestCase) CanonicalIdentity() string {
+	return CanonicalIdentity(t.ClassName, t.Name)
+}
+
+// stripSuffix removes a trailing group delimited by open and close,
+// honoring nesting, eg "[a[0]]".
+func stripSuffix(s string, open, close byte) string {
+	if !strings.HasSuffix(s, string(close)) {
+		return s
+	}
+	depth := 0
+	for i := len(s) - 1; i >= 0; i-- {
+		switch s[i] {
+		case close:
+			depth++
+		case open:
+			depth--
+			if depth == 0 {
+				return s[:i]
+			}
+		}
+	}
+	return s
+}
+
+// stripGenerics removes the generic type parameters of a type name.
+func stripGenerics(s string) string {
+	if !strings.Contains(s, "<") {
+		return s
+	}
+	var b strings.Builder
+	depth := 0
+	for _, r := range s {
+		switch {
+		case r == '<':
+			depth++
+		case r == '>' && depth > 0:
+			depth--
+		case depth == 0:
+			b.WriteRune(r)
+		}
+	}
+	return b.String()
+}
@@ -0,0 +1,37 @@
+package types
+
+import "time"
+
+type JobStatus string
+
+const (
+	JobQueued    JobStatus = "queued"
+	JobRunning   JobStatus = "running"
+	JobSucceeded JobStatus = "succeeded"
+	JobFailed    JobStatus = "failed"
+)
+
+// Done reports whether the job reached a final status.
+func (s JobStatus) Done() bool {
+	return s == JobSucceeded || s == JobFailed
+}
+
+// ReprocessJob is a server side job re-ingesting an uploaded report.
+type ReprocessJob struct {
+	ID        string    `json:"id"`
+	Status    JobStatus `json:"status"`
+	Message   string    `json:"message"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// SelectionTicket tracks a test selection computed asynchronously by the
+// server. Result is set once Status is JobSucceeded.
+type SelectionTicket struct {
+	ID        string           `json:"id"`
+	Status    JobStatus        `json:"status"`
+	Message   string           `json:"message"`
+	Result    *SelectTestsResp `json:"result,omitempty"`
+	CreatedAt time.Time        `json:"created_at"`
+	UpdatedAt time.Time        `json:"updated_at"`
+}
@@ -0,0 +1,20 @@
+package types
+
+// ManualTestResult is the result of a manually executed test. Manual
+// results are recorded in the same reports as automated TestCases but keep
+// track of who executed them and of the evidence collected.
+type ManualTestResult struct {
+	Name       string `json:"name"`
+	SuiteName  string `json:"suite_name"`
+	Result     Result `json:"result"`
+	DurationMs int64  `json:"duration_ms"`
+	// Tester is the person who executed the test.
+	Tester string `json:"tester"`
+	// RunID groups the results of one manual regression run.
+	RunID string `json:"run_id"`
+	// Evidence holds links to screenshots, recordings or logs.
+	Evidence []string `json:"evidence,omitempty"`
+	Notes    string   `json:"notes,omitempty"`
+
+	ExecutedAt *Timestamp `json:"executed_at_ms,omitempty"`
+}
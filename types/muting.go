@@ -0,0 +1,53 @@
+package types
+
+import (
+	"path"
+	"time"
+)
+
+// MutingRule mutes the tests matching Pattern until ExpiresAt, so that
+// known-broken tests don't fail builds.
+type MutingRule struct {
+	ID string `json:"id,omitempty"`
+	// Pattern is a glob (see path.Match) matched against "<class>.<name>"
+	// and against the test file name.
+	Pattern string `json:"pattern"`
+	Reason  string `json:"reason"`
+	// ExpiresAt is nil for rules which never expire.
+	ExpiresAt *time.Time `json:"expires_at,omitempty"`
+	CreatedBy string     `json:"created_by,omitempty"`
+}
+
+// Active reports whether the rule applies at the given time. Rules without
+// an expiry never expire.
+func (r MutingRule) Active(now time.Time) bool {
+	return r.ExpiresAt == nil || r.ExpiresAt.IsZero() || now.Before(*r.ExpiresAt)
+}
+
+// Matches reports whether the rule pattern matches the test case.
+func (r MutingRule) Matches(t *TestCase) bool {
+	if ok, _ := path.Match(r.Pattern, t.ClassName+"."+t.Name); ok {
+		return true
+	}
+	if t.FileName == "" {
+		return false
+	}
+	ok, _ := path.Match(r.Pattern, t.FileName)
+	return ok
+}
+
+// ApplyMutes marks the tests matched by a rule active at now as muted and
+// returns the number of tests muted.
+func ApplyMutes(tests []*TestCase, rules []MutingRule, now time.Time) int {
+	muted := 0
+	for _, t := range tests {
+		for _, r := range rules {
+			if r.Active(now) && r.Matches(t) {
+				t.Muted = true
+				muted++
+				break
+			}
+		}
+	}
+	return muted
+}
@@ -0,0 +1,67 @@
+package types
+
+type OwnerSource string
+
+const (
+	OwnerSourceCodeowners   OwnerSource = "codeowners"
+	OwnerSourceServerConfig OwnerSource = "server_config"
+)
+
+// TestRef identifies a test class or file whose owners are requested.
+type TestRef struct {
+	ClassName string `json:"class_name"`
+	FileName  string `json:"file_name"`
+}
+
+type TestOwner struct {
+	Team   string      `json:"team"`
+	Emails []string    `json:"emails"`
+	Source OwnerSource `json:"source"`
+}
+
+type TestOwnership struct {
+	TestRef
+	Owners []TestOwner `json:"owners"`
+}
+
+type GetTestOwnersReq struct {
+	Tests []TestRef `json:"tests"`
+}
+
+type GetTestOwnersResp struct {
+	Ownership []TestOwnership `json:"ownership"`
+}
+
+// OwnedFailure is a failed test case along with its owners.
+type OwnedFailure struct {
+	Test   *TestCase
+	Owners []TestOwner
+}
+
+// Failures returns the truly failed or errored test cases, ie not muted
+// nor quarantined, along with their owners, matched by class name first
+// and file name second.
+func (r GetTestOwnersResp) Failures(tests []*TestCase) []OwnedFailure {
+	byClass := make(map[string][]TestOwner)
+	byFile := make(map[string][]TestOwner)
+	for _, o := range r.Ownership {
+		if o.ClassName != "" {
+			byClass[o.ClassName] = o.Owners
+		}
+		if o.FileName != "" {
+			byFile[o.FileName] = o.Owners
+		}
+	}
+	var failures []OwnedFailure
+	for _, t := range tests {
+		if !t.IsTrueFailure() {
+			continue
+		}
+		owners, ok := byClass[t.ClassName]
+		if !ok {
+			owners = byFile[t.FileName]
+		}
+		failures = append(failures, OwnedFailure{Test: t, Owners: owners})
+	}
+	return failures
+}
@@ -0,0 +1,107 @@
+package types
+
+// ParameterResult is the result of one invocation of a parameterized test.
+type ParameterResult struct {
+	// Name is the name of the invocation as reported by the runner, eg
+	// "testParse(String)[2]".
+	Name       string `json:"name"`
+	Result     Result `json:"result"`
+	DurationMs int64  `json:"duration_ms"`
+	SystemOut  string `json:"stdout,omitempty"`
+	SystemErr  string `json:"stderr,omitempty"`
+}
+
+// AggregateParameterized groups the invocations of parameterized tests,
+// identified by their canonical name, under a single parent test case
+// with one ParameterResult per invocation. The parent fails if any
+// invocation failed and lasts as long as all of them. Only names shared by
+// at least two invocations are grouped, so that tests merely reported with
+// a signature, such as "testFoo()" by Gradle, are left alone. Other tests
+// are returned unchanged, in order.
+func AggregateParameterized(tests []*TestCase) []*TestCase {
+	invocations := make(map[string]int)
+	for _, t := range tests {
+		if key, ok := parameterizedKey(t); ok {
+			invocations[key]++
+		}
+	}
+	out := make([]*TestCase, 0, len(tests))
+	parents := make(map[string]*TestCase)
+	for _, t := range tests {
+		key, ok := parameterizedKey(t)
+		if !ok || invocations[key] < 2 {
+			out = append(out, t)
+			continue
+		}
+		parent, ok := parents[key]
+		if !ok {
+			parent = &TestCase{
+				Name:        CanonicalMethod(t.Name),
+				ClassName:   t.ClassName,
+				FileName:    t.FileName,
+				SuiteName:   t.SuiteName,
+				Result:      Result{Status: StatusSkipped},
+				Muted:       t.Muted,
+				Quarantined: t.Quarantined,
+				StartTime:   t.StartTime,
+				Issues:      t.Issues,
+			}
+			parents[key] = parent
+			out = append(out, parent)
+		}
+		parent.Parameters = append(parent.Parameters, ParameterResult{
+			Name:       t.Name,
+			Result:     t.Result,
+			DurationMs: t.DurationMs,
+			SystemOut:  t.SystemOut,
+			SystemErr:  t.SystemErr,
+		})
+		parent.DurationMs += t.DurationMs
+		if statusRank(t.Result.Status) > statusRank(parent.Result.Status) {
+			parent.Result = t.Result
+		}
+	}
+	return out
+}
+
+// FlattenParameterized expands the parent test cases built by
+// AggregateParameterized back into one test case per invocation.
+func FlattenParameterized(tests []*TestCase) []*TestCase {
+	out := make([]*TestCase, 0, len(tests))
+	for _, t := range tests {
+		if len(t.Parameters) == 0 {
+			out = append(out, t)
+			continue
+		}
+		for _, p := range t.Parameters {
+			tc := *t
+			tc.Name, tc.Result, tc.DurationMs, tc.Parameters = p.Name, p.Result, p.DurationMs, nil
+			tc.SystemOut, tc.SystemErr = p.SystemOut, p.SystemErr
+			out = append(out, &tc)
+		}
+	}
+	return out
+}
+
+// parameterizedKey returns the key grouping the invocations of the same
+// parameterized test, and false if t is not an invocation.
+func parameterizedKey(t *TestCase) (string, bool) {
+	name := CanonicalMethod(t.Name)
+	if name == t.Name || len(t.Parameters) > 0 {
+		return "", false
+	}
+	return t.ClassName + "#" + name, true
+}
+
+// statusRank orders statuses by severity when aggregating results.
+func statusRank(s Status) int {
+	switch s {
+	case StatusError:
+		return 3
+	case StatusFailed:
+		return 2
+	case StatusPassed:
+		return 1
+	}
+	return 0
+}
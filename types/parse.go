@@ -0,0 +1,89 @@
+package types
+
+import (
+	"fmt"
+	"strings"
+)
+
+var (
+	statusAliases = map[string]Status{
+		"pass":    StatusPassed,
+		"success": StatusPassed,
+		"ok":      StatusPassed,
+		"skip":    StatusSkipped,
+		"ignored": StatusSkipped,
+		"pending": StatusSkipped,
+		"fail":    StatusFailed,
+		"failure": StatusFailed,
+		"errored": StatusError,
+	}
+	fileStatusAliases = map[string]FileStatus{
+		"m":       FileModified,
+		"a":       FileAdded,
+		"d":       FileDeleted,
+		"removed": FileDeleted,
+	}
+)
+
+// ParseStatus returns the Status for s, which must match exactly.
+func ParseStatus(s string) (Status, error) {
+	switch s {
+	case StatusPassed, StatusSkipped, StatusFailed, StatusError:
+		return Status(s), nil
+	}
+	return "", fmt.Errorf("unknown test status %q", s)
+}
+
+// ParseStatusLenient is like ParseStatus but ignores case and surrounding
+// whitespace and accepts common aliases such as "failure" or "pass".
+func ParseStatusLenient(s string) (Status, error) {
+	norm := strings.ToLower(strings.TrimSpace(s))
+	if alias, ok := statusAliases[norm]; ok {
+		return alias, nil
+	}
+	if status, err := ParseStatus(norm); err == nil {
+		return status, nil
+	}
+	return "", fmt.Errorf("unknown test status %q", s)
+}
+
+// ParseFileStatus returns the FileStatus for s, which must match exactly.
+func ParseFileStatus(s string) (FileStatus, error) {
+	switch s {
+	case FileModified, FileAdded, FileDeleted:
+		return FileStatus(s), nil
+	}
+	return "", fmt.Errorf("unknown file status %q", s)
+}
+
+// ParseFileStatusLenient is like ParseFileStatus but ignores case and
+// surrounding whitespace and accepts git's single letter status codes.
+func ParseFileStatusLenient(s string) (FileStatus, error) {
+	norm := strings.ToLower(strings.TrimSpace(s))
+	if alias, ok := fileStatusAliases[norm]; ok {
+		return alias, nil
+	}
+	if status, err := ParseFileStatus(norm); err == nil {
+		return status, nil
+	}
+	return "", fmt.Errorf("unknown file status %q", s)
+}
+
+// ParseSelection returns the Selection for s, which must match exactly.
+func ParseSelection(s string) (Selection, error) {
+	switch s {
+	case SelectSourceCode, SelectNewTest, SelectUpdatedTest, SelectFlakyTest, SelectAlwaysRunTest:
+		return Selection(s), nil
+	}
+	return "", fmt.Errorf("unknown selection reason %q", s)
+}
+
+// ParseSelectionLenient is like ParseSelection but ignores case and
+// surrounding whitespace and accepts dashes in place of underscores.
+func ParseSelectionLenient(s string) (Selection, error) {
+	norm := strings.ReplaceAll(strings.ToLower(strings.TrimSpace(s)), "-", "_")
+	if selection, err := ParseSelection(norm); err == nil {
+		return selection, nil
+	}
+	return "", fmt.Errorf("unknown selection reason %q", s)
+}
@@ -0,0 +1,24 @@
+package types
+
+// PRMetadata describes the pull request a build runs for.
+type PRMetadata struct {
+	Number int      `json:"number"`
+	Title  string   `json:"title,omitempty"`
+	Author string   `json:"author,omitempty"`
+	Labels []string `json:"labels,omitempty"`
+	// Link is the web link of the pull request or of its head commit.
+	Link string `json:"link,omitempty"`
+}
+
+// HasLabel reports whether the pull request carries the label.
+func (p *PRMetadata) HasLabel(label string) bool {
+	if p == nil {
+		return false
+	}
+	for _, l := range p.Labels {
+		if l == label {
+			return true
+		}
+	}
+	return false
+}
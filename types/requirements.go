@@ -0,0 +1,18 @@
+package types
+
+// RequirementCoverageReq asks which executed tests are linked to the given
+// issues or requirements. An empty Issues requests all of them.
+type RequirementCoverageReq struct {
+	Issues []string `json:"issues"`
+}
+
+// RequirementCoverage lists the executed tests linked to an issue or
+// requirement, with their latest status.
+type RequirementCoverage struct {
+	Issue string        `json:"issue"`
+	Tests []TestSummary `json:"tests"`
+}
+
+type RequirementCoverageResp struct {
+	Requirements []RequirementCoverage `json:"requirements"`
+}
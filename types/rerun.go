@@ -0,0 +1,175 @@
+package types
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// RerunFormat is the test filter syntax of a test runner.
+type RerunFormat string
+
+const (
+	// RerunMaven formats a -Dtest filter for the Maven surefire plugin.
+	RerunMaven RerunFormat = "maven"
+	// RerunGradle formats --tests filters for Gradle.
+	RerunGradle RerunFormat = "gradle"
+	// RerunGo formats a -run filter for go test.
+	RerunGo RerunFormat = "go"
+)
+
+// RerunOptions configures NewRerunPlan.
+type RerunOptions struct {
+	// FlakyRetries is the number of retries of failed tests classified as
+	// flaky.
+	FlakyRetries int
+	// StableRetries is the number of retries of the other failed tests.
+	// Zero leaves them out of the plan, so that they fail fast.
+	StableRetries int
+	// FlakyThreshold is the flakiness score from which a test is
+	// classified as flaky.
+	FlakyThreshold float64
+}
+
+// RerunTest is a test to rerun with its retry budget.
+type RerunTest struct {
+	RunnableTest
+	MaxRetries int `json:"max_retries"`
+}
+
+// RerunPlan lists the failed tests to rerun, in the order to run them.
+type RerunPlan struct {
+	Tests []RerunTest `json:"tests"`
+}
+
+// NewRerunPlan builds the plan to rerun the tests which failed or errored.
+// Muted and quarantined tests are ignored. The flakiness of each failed test is looked up
+// in classified, e.g. the tests of a SelectTestsResp. Stable tests run
+// first, then flaky tests from the least to the most flaky, so that true
+// failures surface early.
+func NewRerunPlan(results []*TestCase, classified []RunnableTest, opts RerunOptions) RerunPlan {
+	flakiness := make(map[string]RunnableTest, len(classified))
+	for _, t := range classified {
+		flakiness[t.CanonicalIdentity()] = t
+	}
+	seen := make(map[string]bool)
+	var plan RerunPlan
+	for _, tc := range results {
+		if !tc.IsTrueFailure() {
+			continue
+		}
+		t := runnableTest(tc)
+		if seen[t.CanonicalIdentity()] {
+			continue
+		}
+		seen[t.CanonicalIdentity()] = true
+		if c, ok := flakiness[t.CanonicalIdentity()]; ok {
+			t.Flakiness, t.History = c.Flakiness, c.History
+		}
+		retries := opts.StableRetries
+		if t.IsFlaky(opts.FlakyThreshold) {
+			retries = opts.FlakyRetries
+		}
+		if retries > 0 {
+			plan.Tests = append(plan.Tests, RerunTest{RunnableTest: t, MaxRetries: retries})
+		}
+	}
+	sort.SliceStable(plan.Tests, func(i, j int) bool {
+		return flakinessOf(plan.Tests[i].RunnableTest) < flakinessOf(plan.Tests[j].RunnableTest)
+	})
+	return plan
+}
+
+// runnableTest converts a test result to the test which produced it. A
+// suite-level failure stands for all the tests of its class.
+func runnableTest(tc *TestCase) RunnableTest {
+	t := RunnableTest{Method: tc.Name, Class: tc.ClassName}
+	if tc.IsSuiteFailure() {
+		t.Method = ""
+	}
+	if i := strings.LastIndex(tc.ClassName, "."); i >= 0 {
+		t.Pkg, t.Class = tc.ClassName[:i], tc.ClassName[i+1:]
+	}
+	return t
+}
+
+func flakinessOf(t RunnableTest) float64 {
+	if t.Flakiness == nil {
+		return -1
+	}
+	return *t.Flakiness
+}
+
+// Args formats the tests of the plan as runner arguments.
+func (p RerunPlan) Args(format RerunFormat) ([]string, error) {
+	if len(p.Tests) == 0 {
+		return nil, nil
+	}
+	switch format {
+	case RerunMaven:
+		filters := make([]string, len(p.Tests))
+		for i, t := range p.Tests {
+			filters[i] = qualifiedClass(t.RunnableTest)
+			if t.Method != "" {
+				filters[i] += "#" + CanonicalMethod(t.Method)
+			}
+		}
+		return []string{"-Dtest=" + strings.Join(filters, ",")}, nil
+	case RerunGradle:
+		var args []string
+		for _, t := range p.Tests {
+			filter := qualifiedClass(t.RunnableTest)
+			if t.Method != "" {
+				filter += "." + CanonicalMethod(t.Method)
+			}
+			args = append(args, "--tests", filter)
+		}
+		return args, nil
+	case RerunGo:
+		return goRunArgs(p.Tests), nil
+	}
+	return nil, fmt.Errorf("unknown rerun format %q", format)
+}
+
+// goRunArgs formats a -run filter for go test. go test matches each level
+// of a subtest name, eg "TestA/sub", against the pattern of the same level,
+// so the filter has one alternation per level. Levels below the shallowest
+// test are left out, which reruns all the subtests of the shallower tests.
+func goRunArgs(tests []RerunTest) []string {
+	var names [][]string
+	depth := 0
+	for _, t := range tests {
+		if t.Method == "" {
+			continue
+		}
+		levels := strings.Split(t.Method, "/")
+		if depth == 0 || len(levels) < depth {
+			depth = len(levels)
+		}
+		names = append(names, levels)
+	}
+	if len(names) == 0 {
+		return nil
+	}
+	patterns := make([]string, depth)
+	for i := range patterns {
+		var alts []string
+		seen := make(map[string]bool)
+		for _, levels := range names {
+			if name := regexp.QuoteMeta(levels[i]); !seen[name] {
+				seen[name] = true
+				alts = append(alts, name)
+			}
+		}
+		patterns[i] = "^(" + strings.Join(alts, "|") + ")$"
+	}
+	return []string{"-run", strings.Join(patterns, "/")}
+}
+
+func qualifiedClass(t RunnableTest) string {
+	if t.Pkg == "" {
+		return t.Class
+	}
+	return t.Pkg + "." + t.Class
+}
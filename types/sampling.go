@@ -0,0 +1,62 @@
+package types
+
+import (
+	"hash/fnv"
+	"math"
+)
+
+// SampleResults returns the test cases which aren't passed, all of them,
+// along with a sample of about rate (0-1) of the passed ones. Sampled
+// passed tests carry a SampleWeight such that the weights add up to the
+// number of passed tests, so that counts are preserved. The sample only
+// depends on the raw class and name of the tests, so the same tests are
+// sampled across runs and the invocations of a parameterized test are
+// sampled independently. Tests which already carry a SampleWeight, such as
+// the ones of a retried write, are kept as they are. The given test cases
+// are not modified and the order of the kept ones is preserved.
+func SampleResults(tests []*TestCase, rate float64) []*TestCase {
+	if rate >= 1 {
+		return tests
+	}
+	threshold := uint64(math.Max(rate, 0) * math.MaxUint64)
+	keep := make([]bool, len(tests))
+	passed, sampled, first := 0, 0, -1
+	for i, t := range tests {
+		if t.Result.Status != StatusPassed || t.SampleWeight > 0 {
+			keep[i] = true
+			continue
+		}
+		passed++
+		if first < 0 {
+			first = i
+		}
+		h := fnv.New64a()
+		h.Write([]byte(t.ClassName + "#" + t.Name))
+		if h.Sum64() < threshold {
+			keep[i] = true
+			sampled++
+		}
+	}
+	if passed > 0 && sampled == 0 {
+		// keep a passed test to carry the count
+		keep[first] = true
+		sampled = 1
+	}
+	weight := 0.0
+	if sampled > 0 {
+		weight = float64(passed) / float64(sampled)
+	}
+	out := make([]*TestCase, 0, len(tests)-passed+sampled)
+	for i, t := range tests {
+		if !keep[i] {
+			continue
+		}
+		if t.Result.Status == StatusPassed && t.SampleWeight == 0 {
+			tc := *t
+			tc.SampleWeight = weight
+			t = &tc
+		}
+		out = append(out, t)
+	}
+	return out
+}
@@ -1,6 +1,8 @@
 package types
 
 import (
+	"time"
+
 	"github.com/harness/ti-client/types/cache/buildcache"
 	"github.com/harness/ti-client/types/cache/dlc"
 	"github.com/harness/ti-client/types/cache/gradle"
@@ -20,6 +22,9 @@ const (
 	BUILD_CACHE SavingsFeature = "build_cache"
 	TI          SavingsFeature = "test_intelligence"
 	DLC         SavingsFeature = "docker_layer_caching"
+	// DUPLICATE_TESTS reports the time wasted running the same tests in
+	// several steps of a stage, see FindDuplicateTests
+	DUPLICATE_TESTS SavingsFeature = "duplicate_tests"
 )
 
 type SavingsRequest struct {
@@ -41,3 +46,66 @@ type SavingsResponse struct {
 	DlcMetadata        *dlc.Metadata        `json:"dlc_metadata"`
 	BuildCacheMetadata *buildcache.Metadata `json:"build_cache_metadata"`
 }
+
+// SavingsForecastReq asks for the savings projected from the recent
+// history of the repository.
+type SavingsForecastReq struct {
+	// Features are the features to forecast. Empty means all of them.
+	Features []SavingsFeature `json:"features,omitempty"`
+	// LookbackDays is the history window analyzed, the server default if 0.
+	LookbackDays int `json:"lookback_days,omitempty"`
+}
+
+// FeatureForecast is the projected savings of enabling a feature.
+type FeatureForecast struct {
+	FeatureName SavingsFeature `json:"feature_name"`
+	// CurrentState is the state of the feature in the analyzed builds.
+	CurrentState IntelligenceExecutionState `json:"current_state"`
+	// BuildsAnalyzed is the number of builds the forecast is based on.
+	BuildsAnalyzed int `json:"builds_analyzed"`
+	// SavedMsPerBuild and SavedMsPerMonth are the projected time savings.
+	SavedMsPerBuild int64 `json:"saved_ms_per_build"`
+	SavedMsPerMonth int64 `json:"saved_ms_per_month"`
+	// Confidence (0-1) of the projection.
+	Confidence float64 `json:"confidence"`
+}
+
+type SavingsForecast struct {
+	LookbackDays int               `json:"lookback_days"`
+	Features     []FeatureForecast `json:"features"`
+}
+
+// RollupLevel is the level at which savings are aggregated.
+type RollupLevel string
+
+const (
+	RollupByProject  RollupLevel = "project"
+	RollupByPipeline RollupLevel = "pipeline"
+)
+
+// SavingsScope selects the savings to roll up. An empty OrgID covers the
+// whole account, an empty ProjectID the whole organization.
+type SavingsScope struct {
+	OrgID     string
+	ProjectID string
+	GroupBy   RollupLevel
+}
+
+// SavingsRollupEntry aggregates the savings of a project or pipeline.
+type SavingsRollupEntry struct {
+	OrgID       string `json:"org_id"`
+	ProjectID   string `json:"project_id"`
+	PipelineID  string `json:"pipeline_id,omitempty"`
+	Builds      int    `json:"builds"`
+	TimeTakenMs int64  `json:"time_taken_ms"`
+	TimeSavedMs int64  `json:"time_saved_ms"`
+	// Features breaks TimeSavedMs down per feature.
+	Features map[SavingsFeature]int64 `json:"features"`
+}
+
+type SavingsRollup struct {
+	GroupBy RollupLevel          `json:"group_by"`
+	From    time.Time            `json:"from"`
+	To      time.Time            `json:"to"`
+	Entries []SavingsRollupEntry `json:"entries"`
+}
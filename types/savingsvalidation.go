@@ -0,0 +1,65 @@
+package types
+
+import (
+	"errors"
+	"fmt"
+)
+
+var (
+	savingsFeatures = []SavingsFeature{BUILD_CACHE, TI, DLC, DUPLICATE_TESTS}
+	savingsStates   = []IntelligenceExecutionState{FULL_RUN, OPTIMIZED, DISABLED}
+)
+
+// ValidateSavings reports the inconsistencies of a savings submission
+// which would make the server drop or misreport it: unknown feature or
+// state, negative time, metrics of another feature and optimized runs
+// without the metrics backing the savings.
+func ValidateSavings(feature SavingsFeature, state IntelligenceExecutionState, timeTakenMs int64, req SavingsRequest) error {
+	var errs []error
+	if !containsFeature(feature) {
+		errs = append(errs, fmt.Errorf("unknown savings feature %q, expected one of %v", feature, savingsFeatures))
+	}
+	if !containsState(state) {
+		errs = append(errs, fmt.Errorf("unknown execution state %q, expected one of %v", state, savingsStates))
+	}
+	if timeTakenMs < 0 {
+		errs = append(errs, fmt.Errorf("negative time taken %dms", timeTakenMs))
+	}
+
+	hasDlc := req.DlcMetrics.TotalLayers > 0 || len(req.DlcMetrics.Layers) > 0
+	hasGradle := len(req.GradleMetrics.Profiles) > 0
+	if hasDlc && feature != DLC {
+		errs = append(errs, fmt.Errorf("docker layer caching metrics sent for feature %q, send them with %q only", feature, DLC))
+	}
+	if hasGradle && feature != BUILD_CACHE {
+		errs = append(errs, fmt.Errorf("gradle metrics sent for feature %q, send them with %q only", feature, BUILD_CACHE))
+	}
+	if feature == DLC && state == OPTIMIZED && !hasDlc {
+		errs = append(errs, errors.New("docker layer caching cannot be OPTIMIZED without metrics, set DlcMetrics or report FULL_RUN"))
+	}
+	if feature == DLC && state == DISABLED && hasDlc {
+		errs = append(errs, errors.New("docker layer caching metrics sent while DISABLED, report FULL_RUN or OPTIMIZED"))
+	}
+	if len(errs) == 0 {
+		return nil
+	}
+	return fmt.Errorf("invalid %s savings: %w", feature, errors.Join(errs...))
+}
+
+func containsFeature(f SavingsFeature) bool {
+	for _, known := range savingsFeatures {
+		if f == known {
+			return true
+		}
+	}
+	return false
+}
+
+func containsState(s IntelligenceExecutionState) bool {
+	for _, known := range savingsStates {
+		if s == known {
+			return true
+		}
+	}
+	return false
+}
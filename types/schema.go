@@ -0,0 +1,87 @@
+package types
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// SchemaVersion is the version of the callgraph payload schema, in
+// "<major>.<minor>" form.
+type SchemaVersion string
+
+const (
+	CgSchemaV1_0 SchemaVersion = "1.0"
+	CgSchemaV1_1 SchemaVersion = "1.1"
+
+	// CgSchemaLatest is the newest callgraph schema known to this client.
+	CgSchemaLatest = CgSchemaV1_1
+
+	// cgSchemaCapabilityPrefix prefixes the ServerInfo capabilities
+	// advertising the callgraph schema versions accepted by the server.
+	cgSchemaCapabilityPrefix = "cg_schema:"
+)
+
+// Parse returns the major and minor components of the version.
+func (v SchemaVersion) Parse() (major, minor int, err error) {
+	parts := strings.Split(string(v), ".")
+	if len(parts) != 2 {
+		return 0, 0, fmt.Errorf("invalid schema version %q", string(v))
+	}
+	if major, err = strconv.Atoi(parts[0]); err != nil {
+		return 0, 0, fmt.Errorf("invalid schema version %q", string(v))
+	}
+	if minor, err = strconv.Atoi(parts[1]); err != nil {
+		return 0, 0, fmt.Errorf("invalid schema version %q", string(v))
+	}
+	return major, minor, nil
+}
+
+// Compare returns -1, 0 or 1 if v is older than, equal to or newer than w.
+// Invalid versions compare as older than valid ones.
+func (v SchemaVersion) Compare(w SchemaVersion) int {
+	vMajor, vMinor, vErr := v.Parse()
+	wMajor, wMinor, wErr := w.Parse()
+	switch {
+	case vErr != nil && wErr != nil:
+		return 0
+	case vErr != nil:
+		return -1
+	case wErr != nil:
+		return 1
+	case vMajor != wMajor:
+		return sign(vMajor - wMajor)
+	default:
+		return sign(vMinor - wMinor)
+	}
+}
+
+func sign(n int) int {
+	switch {
+	case n < 0:
+		return -1
+	case n > 0:
+		return 1
+	}
+	return 0
+}
+
+// CgSchemaVersions returns the callgraph schema versions advertised by the
+// server, oldest first. Servers which advertise none accept 1.0 only.
+func (s ServerInfo) CgSchemaVersions() []SchemaVersion {
+	var versions []SchemaVersion
+	for _, c := range s.Capabilities {
+		if strings.HasPrefix(c, cgSchemaCapabilityPrefix) {
+			versions = append(versions, SchemaVersion(strings.TrimPrefix(c, cgSchemaCapabilityPrefix)))
+		}
+	}
+	if len(versions) == 0 {
+		return []SchemaVersion{CgSchemaV1_0}
+	}
+	for i := 1; i < len(versions); i++ {
+		for j := i; j > 0 && versions[j].Compare(versions[j-1]) < 0; j-- {
+			versions[j], versions[j-1] = versions[j-1], versions[j]
+		}
+	}
+	return versions
+}
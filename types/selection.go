@@ -0,0 +1,129 @@
+package types
+
+import "sort"
+
+// FilterBySelection returns the selected tests whose selection reason is one
+// of reasons.
+func (r SelectTestsResp) FilterBySelection(reasons ...Selection) []RunnableTest {
+	var out []RunnableTest
+	for _, t := range r.Tests {
+		for _, reason := range reasons {
+			if t.Selection == reason {
+				out = append(out, t)
+				break
+			}
+		}
+	}
+	return out
+}
+
+// SelectedRatio returns the fraction (0-1) of the tests which were
+// selected. It is 1 when all the tests are selected.
+func (r SelectTestsResp) SelectedRatio() float64 {
+	if r.SelectAll {
+		return 1
+	}
+	if r.TotalTests == 0 {
+		return 0
+	}
+	return float64(r.SelectedTests) / float64(r.TotalTests)
+}
+
+// TestSet is a set of tests keyed by their canonical identity, made of
+// their package qualified class and their method.
+type TestSet map[string]RunnableTest
+
+// Set returns the selected tests as a TestSet.
+func (r SelectTestsResp) Set() TestSet {
+	s := make(TestSet, len(r.Tests))
+	for _, t := range r.Tests {
+		s.Add(t)
+	}
+	return s
+}
+
+// Add adds a test to the set.
+func (s TestSet) Add(t RunnableTest) {
+	s[t.CanonicalIdentity()] = t
+}
+
+// Contains reports whether the set contains the test. A test without a
+// method stands for its whole class and is contained if any test of the
+// class is.
+func (s TestSet) Contains(t RunnableTest) bool {
+	if t.Method != "" {
+		_, ok := s[t.CanonicalIdentity()]
+		return ok
+	}
+	class := canonicalQualifiedClass(t)
+	for _, v := range s {
+		if canonicalQualifiedClass(v) == class {
+			return true
+		}
+	}
+	return false
+}
+
+// Intersect returns the tests of s matched by an entry of include. Entries
+// without a method match every test of their class.
+func (s TestSet) Intersect(include []RunnableTest) TestSet {
+	out := make(TestSet)
+	for k, t := range s {
+		for _, in := range include {
+			if canonicalQualifiedClass(in) == canonicalQualifiedClass(t) && (in.Method == "" || CanonicalMethod(in.Method) == CanonicalMethod(t.Method)) {
+				out[k] = t
+				break
+			}
+		}
+	}
+	return out
+}
+
+// Union returns the tests of s along with the entries of include.
+func (s TestSet) Union(include []RunnableTest) TestSet {
+	out := make(TestSet, len(s)+len(include))
+	for k, t := range s {
+		out[k] = t
+	}
+	for _, t := range include {
+		if _, ok := out[t.CanonicalIdentity()]; !ok {
+			out.Add(t)
+		}
+	}
+	return out
+}
+
+// Tests returns the tests of the set sorted by class and method.
+func (s TestSet) Tests() []RunnableTest {
+	keys := make([]string, 0, len(s))
+	for k := range s {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	tests := make([]RunnableTest, len(keys))
+	for i, k := range keys {
+		tests[i] = s[k]
+	}
+	return tests
+}
+
+// AggregateConfidence returns the confidence of the selection as a whole:
+// the one reported by the server if any, otherwise the lowest confidence of
+// the selected tests. ok is false when no confidence was reported.
+func (r SelectTestsResp) AggregateConfidence() (confidence float64, ok bool) {
+	if r.Confidence != nil {
+		return *r.Confidence, true
+	}
+	for _, t := range r.Tests {
+		if t.Confidence != nil && (!ok || *t.Confidence < confidence) {
+			confidence, ok = *t.Confidence, true
+		}
+	}
+	return confidence, ok
+}
+
+// canonicalQualifiedClass returns the canonical package qualified class of
+// the test.
+func canonicalQualifiedClass(t RunnableTest) string {
+	return CanonicalClass(qualifiedClass(t))
+}
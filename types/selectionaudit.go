@@ -0,0 +1,26 @@
+package types
+
+import "time"
+
+// SelectionRuleResult is a selection rule applied by the server and its
+// effect on the selection.
+type SelectionRuleResult struct {
+	Name        string `json:"name"`
+	Description string `json:"description"`
+	// Tests is the number of tests the rule selected.
+	Tests int `json:"tests"`
+}
+
+// SelectionAudit records how the tests of a step were selected, for
+// archiving why tests were skipped.
+type SelectionAudit struct {
+	StepID    string    `json:"step_id"`
+	Repo      string    `json:"repo"`
+	Sha       string    `json:"sha"`
+	CreatedAt time.Time `json:"created_at"`
+	// Request holds the selection inputs, including the changed files.
+	Request SelectTestsReq        `json:"request"`
+	Rules   []SelectionRuleResult `json:"rules"`
+	// Response is the selection returned to the step.
+	Response SelectTestsResp `json:"response"`
+}
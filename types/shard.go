@@ -0,0 +1,43 @@
+package types
+
+import (
+	"fmt"
+	"hash/fnv"
+)
+
+// Identity returns a stable identifier of the test built from its
+// package, class and method.
+func (t RunnableTest) Identity() string {
+	return t.Pkg + "." + t.Class + "#" + t.Method
+}
+
+// Shard returns the tests assigned to shard index (0-based) out of total
+// shards. The assignment only depends on the canonical identity of each
+// test, so parallel steps can compute their subset independently, even
+// when they name the same test differently; the relative order of the
+// tests is preserved.
+func Shard(tests []RunnableTest, index, total int) ([]RunnableTest, error) {
+	if total <= 0 {
+		return nil, fmt.Errorf("shard total must be positive, got %d", total)
+	}
+	if index < 0 || index >= total {
+		return nil, fmt.Errorf("shard index %d out of range [0, %d)", index, total)
+	}
+	var shard []RunnableTest
+	for _, t := range tests {
+		if ShardOf(t, total) == index {
+			shard = append(shard, t)
+		}
+	}
+	return shard, nil
+}
+
+// ShardOf returns the shard (0-based) out of total which the test belongs to.
+func ShardOf(t RunnableTest, total int) int {
+	if total <= 1 {
+		return 0
+	}
+	h := fnv.New64a()
+	h.Write([]byte(t.CanonicalIdentity()))
+	return int(h.Sum64() % uint64(total))
+}
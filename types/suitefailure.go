@@ -0,0 +1,78 @@
+package types
+
+import "strings"
+
+// SuitePhase identifies the lifecycle phase of a suite in which a
+// suite-level failure happened.
+type SuitePhase string
+
+const (
+	// SuiteSetup represents a failure of the suite setup (eg @BeforeAll),
+	// which aborts all the tests of the suite.
+	SuiteSetup SuitePhase = "setup"
+	// SuiteTeardown represents a failure of the suite teardown (eg
+	// @AfterAll), after its tests ran.
+	SuiteTeardown SuitePhase = "teardown"
+)
+
+// setupFailureNames are the test names under which runners report
+// suite-level failures in JUnit XML reports.
+var (
+	setupFailureNames    = []string{"initializationError", "classMethod", "executionError", "@BeforeClass", "@BeforeAll", "beforeAll", "setUpClass", "setup_class"}
+	teardownFailureNames = []string{"@AfterClass", "@AfterAll", "afterAll", "tearDownClass", "teardown_class"}
+)
+
+// NewSuiteFailure returns the test case recording a failure of the setup
+// or teardown of a suite, distinct from the failures of its tests.
+func NewSuiteFailure(suite, class string, phase SuitePhase, result Result) *TestCase {
+	return &TestCase{Name: "[" + string(phase) + "]", ClassName: class, SuiteName: suite, SuitePhase: phase, Result: result}
+}
+
+// IsSuiteFailure reports whether the test case records a suite-level
+// setup or teardown failure rather than the result of a test.
+func (t *TestCase) IsSuiteFailure() bool {
+	return t.SuitePhase != ""
+}
+
+// DetectSuitePhase returns the phase of the suite-level failure reported
+// as the test case, following the conventions of JUnit, Surefire, Gradle,
+// TestNG and pytest reports, or "" if the test case is a regular test.
+// Report parsers call it to populate TestCase.SuitePhase.
+func DetectSuitePhase(t *TestCase) SuitePhase {
+	if t.Result.Status != StatusFailed && t.Result.Status != StatusError {
+		return ""
+	}
+	name := strings.TrimSpace(t.Name)
+	// Surefire names class-level failures after the class itself
+	if name == "" || name == t.ClassName {
+		return SuiteSetup
+	}
+	for _, n := range teardownFailureNames {
+		if strings.EqualFold(name, n) {
+			return SuiteTeardown
+		}
+	}
+	for _, n := range setupFailureNames {
+		if strings.EqualFold(name, n) {
+			return SuiteSetup
+		}
+	}
+	return ""
+}
+
+// MarkSuiteFailures sets the SuitePhase of the test cases which report
+// suite-level failures and returns their number. The clients call it when
+// writing results; callers ingesting reports by other means must call it
+// themselves.
+func MarkSuiteFailures(tests []*TestCase) int {
+	n := 0
+	for _, t := range tests {
+		if t.SuitePhase == "" {
+			t.SuitePhase = DetectSuitePhase(t)
+		}
+		if t.IsSuiteFailure() {
+			n++
+		}
+	}
+	return n
+}
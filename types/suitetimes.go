@@ -0,0 +1,39 @@
+package types
+
+// TimingLevel is the granularity at which test timings are aggregated.
+type TimingLevel string
+
+const (
+	TimingBySuite TimingLevel = "suite"
+	TimingByClass TimingLevel = "class"
+)
+
+// SuiteTimesReq asks for test timings aggregated per suite or per class.
+type SuiteTimesReq struct {
+	Level TimingLevel `json:"level"`
+	// Names restricts the response to the given suites or classes. An
+	// empty Names returns all of them.
+	Names []string `json:"names,omitempty"`
+}
+
+// SuiteTiming is the total duration of the tests of a suite or class.
+type SuiteTiming struct {
+	Name       string `json:"name"`
+	DurationMs int64  `json:"duration_ms"`
+	Tests      int    `json:"tests"`
+}
+
+type SuiteTimesResp struct {
+	Level   TimingLevel   `json:"level"`
+	Timings []SuiteTiming `json:"timings"`
+}
+
+// TimeMap returns the durations in milliseconds keyed by suite or class
+// name, in the form used by GetTestTimesResp.
+func (r SuiteTimesResp) TimeMap() map[string]int {
+	m := make(map[string]int, len(r.Timings))
+	for _, t := range r.Timings {
+		m[t.Name] = int(t.DurationMs)
+	}
+	return m
+}
@@ -0,0 +1,157 @@
+package types
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+const (
+	// MaxTestDuration is the longest duration considered sane for a single
+	// test case.
+	MaxTestDuration = 7 * 24 * time.Hour
+
+	// DefaultMaxFieldLen is the default limit for free text fields
+	// (message, description, stdout, stderr) applied by Normalize.
+	DefaultMaxFieldLen = 64 * 1024
+)
+
+// Validate reports the problems with a test case which make the server
+// reject or misreport it: missing name or class, unknown status and
+// negative or implausible durations.
+func (t *TestCase) Validate() error {
+	var errs []error
+	if strings.TrimSpace(t.Name) == "" {
+		errs = append(errs, errors.New("test name is not set"))
+	}
+	if strings.TrimSpace(t.ClassName) == "" {
+		errs = append(errs, errors.New("test class name is not set"))
+	}
+	switch t.Result.Status {
+	case StatusPassed, StatusSkipped, StatusFailed, StatusError:
+	default:
+		errs = append(errs, fmt.Errorf("unknown test status %q", string(t.Result.Status)))
+	}
+	if t.DurationMs < 0 {
+		errs = append(errs, fmt.Errorf("negative test duration %dms", t.DurationMs))
+	} else if time.Duration(t.DurationMs)*time.Millisecond > MaxTestDuration {
+		errs = append(errs, fmt.Errorf("test duration %dms exceeds %s", t.DurationMs, MaxTestDuration))
+	}
+	if len(errs) == 0 {
+		return nil
+	}
+	return fmt.Errorf("invalid test case %s.%s: %w", t.ClassName, t.Name, errors.Join(errs...))
+}
+
+// IsTrueFailure reports whether the test failed or errored without being
+// muted or quarantined, i.e. whether it should fail the build.
+func (t *TestCase) IsTrueFailure() bool {
+	if t.Muted || t.Quarantined {
+		return false
+	}
+	return t.Result.Status == StatusFailed || t.Result.Status == StatusError
+}
+
+// Normalize trims surrounding whitespace from identifying fields, lower
+// cases the status and limits free text fields to maxLen bytes
+// (DefaultMaxFieldLen if maxLen <= 0).
+func (t *TestCase) Normalize(maxLen int) {
+	if maxLen <= 0 {
+		maxLen = DefaultMaxFieldLen
+	}
+	t.Name = strings.TrimSpace(t.Name)
+	t.ClassName = strings.TrimSpace(t.ClassName)
+	t.FileName = strings.TrimSpace(t.FileName)
+	t.SuiteName = strings.TrimSpace(t.SuiteName)
+	t.Result.Status = Status(strings.ToLower(strings.TrimSpace(string(t.Result.Status))))
+	t.Result.Message = truncate(t.Result.Message, maxLen)
+	t.Result.Desc = truncate(t.Result.Desc, maxLen)
+	t.SystemOut = truncate(t.SystemOut, maxLen)
+	t.SystemErr = truncate(t.SystemErr, maxLen)
+}
+
+// truncate limits s to n bytes without splitting a UTF-8 sequence.
+func truncate(s string, n int) string {
+	if len(s) <= n {
+		return s
+	}
+	for n > 0 && !utf8.RuneStart(s[n]) {
+		n--
+	}
+	return s[:n]
+}
+
+// TestCaseBuilder builds validated, normalized test cases.
+type TestCaseBuilder struct {
+	tc     TestCase
+	maxLen int
+}
+
+// NewTestCase starts building a test case with the given class and name.
+func NewTestCase(className, name string) *TestCaseBuilder {
+	return &TestCaseBuilder{tc: TestCase{ClassName: className, Name: name}}
+}
+
+func (b *TestCaseBuilder) File(file string) *TestCaseBuilder {
+	b.tc.FileName = file
+	return b
+}
+
+func (b *TestCaseBuilder) Suite(suite string) *TestCaseBuilder {
+	b.tc.SuiteName = suite
+	return b
+}
+
+func (b *TestCaseBuilder) Status(status Status) *TestCaseBuilder {
+	b.tc.Result.Status = status
+	return b
+}
+
+// Failure sets the failure details of the test case.
+func (b *TestCaseBuilder) Failure(message, typ, desc string) *TestCaseBuilder {
+	b.tc.Result.Message = message
+	b.tc.Result.Type = typ
+	b.tc.Result.Desc = desc
+	return b
+}
+
+func (b *TestCaseBuilder) Duration(d time.Duration) *TestCaseBuilder {
+	b.tc.DurationMs = d.Milliseconds()
+	return b
+}
+
+func (b *TestCaseBuilder) Output(stdout, stderr string) *TestCaseBuilder {
+	b.tc.SystemOut = stdout
+	b.tc.SystemErr = stderr
+	return b
+}
+
+// Muted marks the test case as matched by a muting rule.
+func (b *TestCaseBuilder) Muted() *TestCaseBuilder {
+	b.tc.Muted = true
+	return b
+}
+
+// Quarantined marks the test case as quarantined.
+func (b *TestCaseBuilder) Quarantined() *TestCaseBuilder {
+	b.tc.Quarantined = true
+	return b
+}
+
+// MaxFieldLen sets the limit applied to free text fields.
+func (b *TestCaseBuilder) MaxFieldLen(n int) *TestCaseBuilder {
+	b.maxLen = n
+	return b
+}
+
+// Build normalizes and validates the test case.
+func (b *TestCaseBuilder) Build() (*TestCase, error) {
+	tc := b.tc
+	tc.Normalize(b.maxLen)
+	if err := tc.Validate(); err != nil {
+		return nil, err
+	}
+	return &tc, nil
+}
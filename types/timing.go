@@ -0,0 +1,131 @@
+package types
+
+import (
+	"encoding/json"
+	"math"
+	"sort"
+	"time"
+)
+
+// Timestamp is a time.Time encoded in JSON as integer milliseconds since
+// the Unix epoch.
+type Timestamp time.Time
+
+func (t Timestamp) MarshalJSON() ([]byte, error) {
+	return json.Marshal(time.Time(t).UnixMilli())
+}
+
+func (t *Timestamp) UnmarshalJSON(data []byte) error {
+	var ms int64
+	if err := json.Unmarshal(data, &ms); err != nil {
+		return err
+	}
+	*t = Timestamp(time.UnixMilli(ms))
+	return nil
+}
+
+// Duration returns the duration of the test case.
+func (t *TestCase) Duration() time.Duration {
+	return time.Duration(t.DurationMs) * time.Millisecond
+}
+
+// SetDuration sets the duration of the test case.
+func (t *TestCase) SetDuration(d time.Duration) {
+	t.DurationMs = d.Milliseconds()
+}
+
+// Started returns the start time of the test case, or the zero time if
+// it is not known.
+func (t *TestCase) Started() time.Time {
+	if t.StartTime == nil {
+		return time.Time{}
+	}
+	return time.Time(*t.StartTime)
+}
+
+// SetStarted sets the start time of the test case.
+func (t *TestCase) SetStarted(start time.Time) {
+	ts := Timestamp(start)
+	t.StartTime = &ts
+}
+
+// Finished returns the end time of the test case, or the zero time if its
+// start time is not known.
+func (t *TestCase) Finished() time.Time {
+	if t.StartTime == nil {
+		return time.Time{}
+	}
+	return t.Started().Add(t.Duration())
+}
+
+// weight returns the number of tests the test case stands for, more than
+// one for a sampled passed test.
+func (t *TestCase) weight() float64 {
+	if t.SampleWeight > 0 {
+		return t.SampleWeight
+	}
+	return 1
+}
+
+// TotalDuration returns the sum of the durations of the test cases, with
+// sampled passed tests weighted by their SampleWeight. Suite-level
+// failures are not tests and are left out.
+func TotalDuration(tests []*TestCase) time.Duration {
+	var total float64
+	for _, t := range tests {
+		if t.IsSuiteFailure() {
+			continue
+		}
+		total += float64(t.Duration()) * t.weight()
+	}
+	return time.Duration(total)
+}
+
+// SummarizeSuites aggregates test cases into per suite totals, sorted by
+// suite name. Sampled passed tests are weighted by their SampleWeight,
+// muted and quarantined failures are counted as skipped and suite-level
+// failures are left out.
+func SummarizeSuites(tests []*TestCase) []TestSuite {
+	type totals struct {
+		duration, total, failed, skipped, passed float64
+	}
+	bySuite := make(map[string]*totals)
+	for _, t := range tests {
+		if t.IsSuiteFailure() {
+			continue
+		}
+		s, ok := bySuite[t.SuiteName]
+		if !ok {
+			s = &totals{}
+			bySuite[t.SuiteName] = s
+		}
+		w := t.weight()
+		s.total += w
+		s.duration += float64(t.DurationMs) * w
+		switch {
+		case t.IsTrueFailure():
+			s.failed += w
+		case t.Result.Status == StatusSkipped, t.Result.Status == StatusFailed, t.Result.Status == StatusError:
+			s.skipped += w
+		default:
+			s.passed += w
+		}
+	}
+	suites := make([]TestSuite, 0, len(bySuite))
+	for name, s := range bySuite {
+		suite := TestSuite{
+			Name:         name,
+			DurationMs:   int64(math.Round(s.duration)),
+			TotalTests:   int(math.Round(s.total)),
+			FailedTests:  int(math.Round(s.failed)),
+			SkippedTests: int(math.Round(s.skipped)),
+			PassedTests:  int(math.Round(s.passed)),
+		}
+		if suite.TotalTests > 0 {
+			suite.FailPct = suite.FailedTests * 100 / suite.TotalTests
+		}
+		suites = append(suites, suite)
+	}
+	sort.Slice(suites, func(i, j int) bool { return suites[i].Name < suites[j].Name })
+	return suites
+}
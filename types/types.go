@@ -79,16 +79,17 @@ const (
 	HarnessInfra = "VM"
 )
 
+// ConvertToFileStatus returns the FileStatus for s, defaulting to
+// FileModified for unknown values.
+//
+// Deprecated: use ParseFileStatus or ParseFileStatusLenient, which report
+// unknown values instead of hiding them.
 func ConvertToFileStatus(s string) FileStatus {
-	switch s {
-	case FileModified:
+	status, err := ParseFileStatus(s)
+	if err != nil {
 		return FileModified
-	case FileAdded:
-		return FileAdded
-	case FileDeleted:
-		return FileDeleted
 	}
-	return FileModified
+	return status
 }
 
 type Result struct {
@@ -124,6 +125,24 @@ type TestCase struct {
 	DurationMs int64  `json:"duration_ms"`
 	SystemOut  string `json:"stdout"`
 	SystemErr  string `json:"stderr"`
+	Muted      bool   `json:"muted,omitempty"` // matched by an active muting rule
+	// Quarantined tests are excluded from the build outcome while they
+	// are investigated, their failures are reported separately.
+	Quarantined bool `json:"quarantined,omitempty"`
+
+	StartTime *Timestamp `json:"start_time_ms,omitempty"`
+	// Issues are the keys of the issues or requirements the test covers,
+	// e.g. Jira keys.
+	Issues []string `json:"issues,omitempty"`
+	// Parameters holds the results of the invocations of a parameterized
+	// test aggregated under this test, see AggregateParameterized.
+	Parameters []ParameterResult `json:"parameters,omitempty"`
+	// SuitePhase is set when the test case records a failure of the setup
+	// or teardown of its suite rather than the result of a test.
+	SuitePhase SuitePhase `json:"suite_phase,omitempty"`
+	// SampleWeight is the number of passed tests a sampled passed test
+	// stands for, see SampleResults. Zero means the test isn't sampled.
+	SampleWeight float64 `json:"sample_weight,omitempty"`
 }
 
 type TestSummary struct {
@@ -131,6 +150,24 @@ type TestSummary struct {
 	Status Status `json:"status"`
 }
 
+// BasicInfo identifies the pipeline execution, stage and step a read
+// request refers to. It carries the defaults of the WithDefaults methods
+// of read requests, whose identifiers are kept as flat fields for
+// compatibility.
+type BasicInfo struct {
+	OrgID      string
+	ProjectID  string
+	PipelineID string
+	BuildID    string
+	StageID    string
+	StepID     string
+
+	// ParentPipelineID and ParentBuildID identify the parent pipeline
+	// execution of a child pipeline.
+	ParentPipelineID string
+	ParentBuildID    string
+}
+
 type SummaryRequest struct {
 	AllStages  bool
 	OrgID      string
@@ -139,14 +176,19 @@ type SummaryRequest struct {
 	BuildID    string
 	StageID    string
 	StepID     string
-	ReportType string
+	ReportType ReportType
+
+	// ParentPipelineID and ParentBuildID identify the parent pipeline
+	// execution of a child pipeline.
+	ParentPipelineID string
+	ParentBuildID    string
 }
 
 type TestCasesRequest struct {
 	BasicInfo          SummaryRequest
 	TestCaseSearchTerm string
-	Sort               string
-	Order              string
+	Sort               SortField
+	Order              SortOrder
 	PageIndex          string
 	PageSize           string
 	SuiteName          string
@@ -158,6 +200,15 @@ type SummaryResponse struct {
 	SuccessfulTests int   `json:"successful_tests"`
 	SkippedTests    int   `json:"skipped_tests"`
 	TimeMs          int64 `json:"duration_ms"`
+	// PR describes the pull request the tests ran for, if any
+	PR *PRMetadata `json:"pr,omitempty"`
+	// MutedTests and QuarantinedTests count the failed tests which were
+	// muted or quarantined. They are not counted in FailedTests.
+	MutedTests       int `json:"muted_tests,omitempty"`
+	QuarantinedTests int `json:"quarantined_tests,omitempty"`
+	// SuiteFailures counts the suite setup and teardown failures, which
+	// are not tests and not counted in TotalTests.
+	SuiteFailures int `json:"suite_failures,omitempty"`
 }
 
 type StepInfo struct {
@@ -189,6 +240,14 @@ type RunnableTest struct {
 		// auto-detection info depending on the runner
 		Rule string `json:"rule"` // bazel
 	} `json:"autodetect"`
+	// Confidence (0-1) of ML based selection that the test is relevant to the change
+	Confidence *float64 `json:"confidence,omitempty"`
+	// Score ranks the selected tests, higher runs first
+	Score float64 `json:"score,omitempty"`
+	// Flakiness (0-1) is the fraction of recent runs with inconsistent results
+	Flakiness *float64 `json:"flakiness,omitempty"`
+	// History summarizes the recent results of the test
+	History *TestHistory `json:"history,omitempty"`
 }
 
 type SelectTestsResp struct {
@@ -199,6 +258,8 @@ type SelectTestsResp struct {
 	SrcCodeTests  int            `json:"src_code_tests"`
 	SelectAll     bool           `json:"select_all"` // We might choose to run all the tests
 	Tests         []RunnableTest `json:"tests"`
+	// Confidence (0-1) of ML based selection in the selection as a whole
+	Confidence *float64 `json:"confidence,omitempty"`
 }
 
 type SelectTestsReq struct {
@@ -212,6 +273,20 @@ type SelectTestsReq struct {
 	TiConfig     TiConfig `json:"ti_config"`
 	TestGlobs    []string `json:"test_globs"`
 	Language     string   `json:"language"`
+	// ChangedDependencies are the dependency version changes found in the
+	// changed lockfiles, so that dependency bumps select the affected tests.
+	ChangedDependencies []DependencyChange `json:"changed_dependencies,omitempty"`
+	// PR describes the pull request of the build, if any
+	PR *PRMetadata `json:"pr,omitempty"`
+}
+
+// DependencyChange is a dependency added, removed or bumped in a lockfile.
+// OldVersion is empty for added dependencies and NewVersion for removed ones.
+type DependencyChange struct {
+	Lockfile   string `json:"lockfile"`
+	Name       string `json:"name"`
+	OldVersion string `json:"old_version,omitempty"`
+	NewVersion string `json:"new_version,omitempty"`
 }
 
 type SelectionDetails struct {
@@ -254,6 +329,10 @@ type File struct {
 type DownloadLink struct {
 	URL     string `json:"url"`
 	RelPath string `json:"rel_path"` // this is the relative path to the artifact from the base URL
+	// SignatureURL points to a detached signature of the artifact, if signed.
+	SignatureURL string `json:"signature_url,omitempty"`
+	// PublicKeyID names the key the artifact was signed with.
+	PublicKeyID string `json:"public_key_id,omitempty"`
 }
 
 // This is a yaml file which may or may not exist in the root of the source code
@@ -266,11 +345,16 @@ type DownloadLink struct {
 //	   - config.sh
 //	enableBazelOptimization: true
 //	bazelFileCountThreshold: 100
+//	alwaysRun:
+//	   - io.harness.SmokeTest
+//	   - io.harness.LoginTest#testLogin
 type TiConfig struct {
 	Config struct {
 		Ignore                  []string `json:"ignore"`
 		BazelOptimization       bool     `yaml:"enableBazelOptimization"`
 		BazelFileCountThreshold int      `yaml:"bazelFileCountThreshold"`
+		// AlwaysRun lists tests which are never skipped, see ParseTestRefs
+		AlwaysRun []string `json:"alwaysRun,omitempty" yaml:"alwaysRun"`
 	}
 }
 
@@ -345,6 +429,8 @@ type MLSelectTestsRequest struct {
 	Files               []File              `json:"files"`
 	Specs               map[string]string   `json:"specs"`
 	TestRunner          string              `json:"test_runner"`
+	// PR describes the pull request of the build, if any
+	PR *PRMetadata `json:"pr,omitempty"`
 }
 
 type MLServiceAPIRequest struct {
@@ -361,3 +447,32 @@ type MLServiceAPIRequest struct {
 	PRDeletions  int      `json:"pr_deletions"`
 	Authors      string   `json:"authors"`
 }
+
+// ServerInfo describes the TI server a client is talking to
+type ServerInfo struct {
+	Version      string   `json:"version"`
+	Region       string   `json:"region"`
+	Capabilities []string `json:"capabilities"`
+	APIVersions  []string `json:"api_versions"`
+}
+
+// HasCapability reports whether the server advertises the given capability.
+func (s ServerInfo) HasCapability(name string) bool {
+	for _, c := range s.Capabilities {
+		if c == name {
+			return true
+		}
+	}
+	return false
+}
+
+type ImpactedTestsReq struct {
+	Files []File `json:"files"`
+}
+
+type ImpactedTestsResp struct {
+	// SelectAll is set when the changes can't be mapped to tests (eg build
+	// files were changed) and all the tests are impacted.
+	SelectAll bool           `json:"select_all"`
+	Tests     []RunnableTest `json:"tests"`
+}
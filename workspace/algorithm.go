@@ -0,0 +1,45 @@
+package workspace
+
+import (
+	"crypto/sha256"
+	"fmt"
+	"hash"
+)
+
+// ChecksumAlgorithm is the algorithm of file checksums. It should be
+// carried along with the checksums, so that their consumer knows which
+// algorithm produced them.
+type ChecksumAlgorithm string
+
+const (
+	// ChecksumXXHash64 is the fast non-cryptographic 64-bit xxHash. This
+	// is the default.
+	ChecksumXXHash64 ChecksumAlgorithm = "xxhash64"
+	// ChecksumSHA256 is SHA-256, for FIPS constrained environments.
+	ChecksumSHA256 ChecksumAlgorithm = "sha256"
+)
+
+// OrDefault returns a, or the default algorithm if a is empty.
+func (a ChecksumAlgorithm) OrDefault() ChecksumAlgorithm {
+	if a == "" {
+		return ChecksumXXHash64
+	}
+	return a
+}
+
+// Validate returns an error if a is not a known algorithm. The empty
+// algorithm stands for the default one.
+func (a ChecksumAlgorithm) Validate() error {
+	switch a.OrDefault() {
+	case ChecksumXXHash64, ChecksumSHA256:
+		return nil
+	}
+	return fmt.Errorf("unknown checksum algorithm %q", string(a))
+}
+
+func (a ChecksumAlgorithm) newHash() hash.Hash {
+	if a.OrDefault() == ChecksumSHA256 {
+		return sha256.New()
+	}
+	return newXXHash64()
+}
@@ -0,0 +1,135 @@
+package workspace
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// DefaultLockfiles are the dependency lockfiles included in a fingerprint,
+// as globs relative to the workspace root.
+var DefaultLockfiles = []string{
+	"go.sum",
+	"package-lock.json",
+	"yarn.lock",
+	"pnpm-lock.yaml",
+	"Cargo.lock",
+	"poetry.lock",
+	"Pipfile.lock",
+	"Gemfile.lock",
+	"composer.lock",
+	"gradle.lockfile",
+	"*/gradle.lockfile",
+}
+
+// FingerprintOptions configures NewFingerprint.
+type FingerprintOptions struct {
+	// Toolchains maps a toolchain name to the command printing its version,
+	// eg "go": {"go", "version"}.
+	Toolchains map[string][]string
+	// Env lists the environment variables covered by the fingerprint. Only
+	// a hash of their values is kept.
+	Env []string
+	// Lockfiles are globs relative to the root. DefaultLockfiles is used if
+	// nil.
+	Lockfiles []string
+	// Algorithm checksums the lockfiles, xxhash64 if empty.
+	Algorithm ChecksumAlgorithm
+}
+
+// Fingerprint describes the build environment of a workspace, so that skip
+// decisions can be invalidated when it changes even if the source files
+// don't.
+type Fingerprint struct {
+	Toolchains map[string]string `json:"toolchains,omitempty"`
+	EnvHash    string            `json:"env_hash,omitempty"`
+	Lockfiles  map[string]string `json:"lockfiles,omitempty"`
+	// Algorithm is the algorithm of the lockfile checksums.
+	Algorithm ChecksumAlgorithm `json:"checksum_algorithm,omitempty"`
+}
+
+// NewFingerprint computes the fingerprint of the workspace at root.
+func NewFingerprint(ctx context.Context, root string, opts FingerprintOptions) (Fingerprint, error) {
+	fp := Fingerprint{
+		Toolchains: make(map[string]string),
+		Lockfiles:  make(map[string]string),
+		Algorithm:  opts.Algorithm.OrDefault(),
+	}
+	if err := opts.Algorithm.Validate(); err != nil {
+		return fp, err
+	}
+	for name, cmd := range opts.Toolchains {
+		if len(cmd) == 0 {
+			continue
+		}
+		out, err := exec.CommandContext(ctx, cmd[0], cmd[1:]...).CombinedOutput() //nolint:gosec
+		if err != nil {
+			return fp, fmt.Errorf("could not get %s version: %w", name, err)
+		}
+		fp.Toolchains[name] = strings.TrimSpace(string(out))
+	}
+
+	if len(opts.Env) > 0 {
+		vars := append([]string(nil), opts.Env...)
+		sort.Strings(vars)
+		h := sha256.New()
+		for _, v := range vars {
+			fmt.Fprintf(h, "%s=%s\n", v, os.Getenv(v))
+		}
+		fp.EnvHash = hex.EncodeToString(h.Sum(nil))
+	}
+
+	patterns := opts.Lockfiles
+	if patterns == nil {
+		patterns = DefaultLockfiles
+	}
+	for _, pattern := range patterns {
+		matches, err := filepath.Glob(filepath.Join(root, filepath.FromSlash(pattern)))
+		if err != nil {
+			return fp, err
+		}
+		for _, m := range matches {
+			sum, err := fileChecksum(m, BinaryHash, fp.Algorithm)
+			if err != nil {
+				return fp, err
+			}
+			rel, err := filepath.Rel(root, m)
+			if err != nil {
+				return fp, err
+			}
+			fp.Lockfiles[filepath.ToSlash(rel)] = sum
+		}
+	}
+	return fp, nil
+}
+
+// Digest returns a single hash covering the whole fingerprint.
+func (f Fingerprint) Digest() string {
+	h := sha256.New()
+	for _, k := range sortedKeys(f.Toolchains) {
+		fmt.Fprintf(h, "toolchain %s %s\n", k, f.Toolchains[k])
+	}
+	fmt.Fprintf(h, "env %s\n", f.EnvHash)
+	if f.Algorithm != "" {
+		fmt.Fprintf(h, "algorithm %s\n", f.Algorithm)
+	}
+	for _, k := range sortedKeys(f.Lockfiles) {
+		fmt.Fprintf(h, "lockfile %s %s\n", k, f.Lockfiles[k])
+	}
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+func sortedKeys(m map[string]string) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,128 @@
+package workspace
+
+import (
+	"bufio"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/harness/ti-client/types"
+)
+
+// DefaultGeneratedPatterns match lockfiles and protobuf outputs.
+var DefaultGeneratedPatterns = []string{
+	"go.sum",
+	"package-lock.json",
+	"yarn.lock",
+	"pnpm-lock.yaml",
+	"Cargo.lock",
+	"poetry.lock",
+	"Pipfile.lock",
+	"Gemfile.lock",
+	"composer.lock",
+	"gradle.lockfile",
+	"*.pb.go",
+	"*.pb.gw.go",
+	"*_pb2.py",
+	"*_pb2_grpc.py",
+	"*.pb.cc",
+	"*.pb.h",
+	"*_pb.js",
+	"*_pb.d.ts",
+}
+
+// generatedHeader is the marker of generated Go files, see
+// https://golang.org/s/generatedcode. Other generators commonly use the same
+// comment with their own comment syntax.
+var generatedHeader = regexp.MustCompile(`^\s*(//|#|/\*|--)\s*Code generated .* DO NOT EDIT\.`)
+
+// generatedHeaderLines is the number of leading lines searched for the
+// generated code marker.
+const generatedHeaderLines = 5
+
+// GeneratedFiles detects generated files, whose regeneration noise
+// shouldn't defeat test skipping.
+type GeneratedFiles struct {
+	// Patterns are globs (see path.Match) matched against the file name,
+	// or against the slash separated path if they contain a slash.
+	Patterns []string
+	// SkipHeader disables the detection of the "Code generated ... DO NOT
+	// EDIT." marker in the first lines of files.
+	SkipHeader bool
+}
+
+// DefaultGeneratedFiles detects the files matching DefaultGeneratedPatterns
+// or carrying the generated code marker.
+func DefaultGeneratedFiles() GeneratedFiles {
+	return GeneratedFiles{Patterns: DefaultGeneratedPatterns}
+}
+
+// MatchesPattern reports whether the slash separated path p matches one of
+// the patterns.
+func (g GeneratedFiles) MatchesPattern(p string) bool {
+	for _, pattern := range g.Patterns {
+		name := path.Base(p)
+		if strings.Contains(pattern, "/") {
+			name = p
+		}
+		if ok, _ := path.Match(pattern, name); ok {
+			return true
+		}
+	}
+	return false
+}
+
+// IsGenerated reports whether the file at path p, relative to root, is
+// generated.
+func (g GeneratedFiles) IsGenerated(root, p string) bool {
+	if g.MatchesPattern(p) {
+		return true
+	}
+	if g.SkipHeader {
+		return false
+	}
+	return hasGeneratedHeader(filepath.Join(root, filepath.FromSlash(p)))
+}
+
+// Exclude returns a WalkOptions.Exclude function leaving generated files
+// below root out of the checksums.
+func (g GeneratedFiles) Exclude(root string) func(string, fs.DirEntry) bool {
+	return func(p string, d fs.DirEntry) bool {
+		return !d.IsDir() && g.IsGenerated(root, p)
+	}
+}
+
+// Filter returns the changed files which are not generated, for use as
+// selection inputs. Deleted files are only matched against the patterns.
+func (g GeneratedFiles) Filter(root string, files []types.File) []types.File {
+	out := make([]types.File, 0, len(files))
+	for _, f := range files {
+		if f.Status == types.FileDeleted {
+			if g.MatchesPattern(f.Name) {
+				continue
+			}
+		} else if g.IsGenerated(root, f.Name) {
+			continue
+		}
+		out = append(out, f)
+	}
+	return out
+}
+
+func hasGeneratedHeader(name string) bool {
+	f, err := os.Open(name)
+	if err != nil {
+		return false
+	}
+	defer f.Close()
+	s := bufio.NewScanner(f)
+	for i := 0; i < generatedHeaderLines && s.Scan(); i++ {
+		if generatedHeader.Match(s.Bytes()) {
+			return true
+		}
+	}
+	return false
+}
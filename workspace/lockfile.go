@@ -0,0 +1,160 @@
+package workspace
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"path"
+	"sort"
+	"strings"
+
+	"github.com/harness/ti-client/types"
+)
+
+// ParseLockfile returns the dependency versions pinned by a lockfile, keyed
+// by dependency name. The format is detected from the file name; go.sum,
+// package-lock.json and gradle.lockfile are supported. Dependencies pinned
+// at several versions have them joined by commas.
+func ParseLockfile(name string, data []byte) (map[string]string, error) {
+	var (
+		versions map[string][]string
+		err      error
+	)
+	switch base := path.Base(name); {
+	case base == "go.sum":
+		versions, err = parseGoSum(data)
+	case base == "package-lock.json":
+		versions, err = parsePackageLock(data)
+	case strings.HasSuffix(base, ".lockfile"):
+		versions, err = parseGradleLockfile(data)
+	default:
+		return nil, fmt.Errorf("unsupported lockfile %s", name)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("could not parse %s: %w", name, err)
+	}
+	deps := make(map[string]string, len(versions))
+	for dep, v := range versions {
+		sort.Strings(v)
+		deps[dep] = strings.Join(dedup(v), ",")
+	}
+	return deps, nil
+}
+
+// IsLockfile reports whether ParseLockfile supports the file.
+func IsLockfile(name string) bool {
+	base := path.Base(name)
+	return base == "go.sum" || base == "package-lock.json" || strings.HasSuffix(base, ".lockfile")
+}
+
+// DependencyChanges compares two revisions of a lockfile and returns the
+// dependencies which were added, removed or changed version, sorted by
+// name. A nil revision stands for a missing file.
+func DependencyChanges(name string, oldData, newData []byte) ([]types.DependencyChange, error) {
+	before, after := map[string]string{}, map[string]string{}
+	var err error
+	if oldData != nil {
+		if before, err = ParseLockfile(name, oldData); err != nil {
+			return nil, err
+		}
+	}
+	if newData != nil {
+		if after, err = ParseLockfile(name, newData); err != nil {
+			return nil, err
+		}
+	}
+	var changes []types.DependencyChange
+	for dep, v := range after {
+		if before[dep] != v {
+			changes = append(changes, types.DependencyChange{Lockfile: name, Name: dep, OldVersion: before[dep], NewVersion: v})
+		}
+	}
+	for dep, v := range before {
+		if _, ok := after[dep]; !ok {
+			changes = append(changes, types.DependencyChange{Lockfile: name, Name: dep, OldVersion: v})
+		}
+	}
+	sort.Slice(changes, func(i, j int) bool {
+		return changes[i].Name < changes[j].Name
+	})
+	return changes, nil
+}
+
+func parseGoSum(data []byte) (map[string][]string, error) {
+	versions := make(map[string][]string)
+	s := bufio.NewScanner(bytes.NewReader(data))
+	for s.Scan() {
+		fields := strings.Fields(s.Text())
+		if len(fields) == 0 {
+			continue
+		}
+		if len(fields) != 3 {
+			return nil, fmt.Errorf("malformed line %q", s.Text())
+		}
+		mod, version := fields[0], strings.TrimSuffix(fields[1], "/go.mod")
+		versions[mod] = append(versions[mod], version)
+	}
+	return versions, s.Err()
+}
+
+func parsePackageLock(data []byte) (map[string][]string, error) {
+	var lock struct {
+		Packages map[string]struct {
+			Version string `json:"version"`
+		} `json:"packages"`
+		Dependencies map[string]struct {
+			Version string `json:"version"`
+		} `json:"dependencies"`
+	}
+	if err := json.Unmarshal(data, &lock); err != nil {
+		return nil, err
+	}
+	versions := make(map[string][]string)
+	if len(lock.Packages) > 0 {
+		// lockfileVersion 2 and 3, keyed by install path
+		for p, pkg := range lock.Packages {
+			i := strings.LastIndex(p, "node_modules/")
+			if i < 0 || pkg.Version == "" {
+				continue
+			}
+			dep := p[i+len("node_modules/"):]
+			versions[dep] = append(versions[dep], pkg.Version)
+		}
+		return versions, nil
+	}
+	for dep, pkg := range lock.Dependencies {
+		versions[dep] = append(versions[dep], pkg.Version)
+	}
+	return versions, nil
+}
+
+func parseGradleLockfile(data []byte) (map[string][]string, error) {
+	versions := make(map[string][]string)
+	s := bufio.NewScanner(bytes.NewReader(data))
+	for s.Scan() {
+		line := strings.TrimSpace(s.Text())
+		if line == "" || strings.HasPrefix(line, "#") || strings.HasPrefix(line, "empty=") {
+			continue
+		}
+		coords, _, _ := strings.Cut(line, "=")
+		i := strings.LastIndex(coords, ":")
+		if i < 0 {
+			return nil, fmt.Errorf("malformed line %q", line)
+		}
+		dep := coords[:i]
+		versions[dep] = append(versions[dep], coords[i+1:])
+	}
+	return versions, s.Err()
+}
+
+// dedup removes adjacent duplicates from a sorted slice.
+func dedup(s []string) []string {
+	out := s[:0]
+	for i, v := range s {
+		if i == 0 || v != s[i-1] {
+			out = append(out, v)
+		}
+	}
+	return out
+}
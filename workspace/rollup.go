@@ -0,0 +1,81 @@
+// Package workspace contains helpers describing the state of a source
+// workspace, used to decide whether the results of previous runs still
+// apply.
+package workspace
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"path"
+	"sort"
+)
+
+// RollupChecksums computes a digest per directory from the checksums of the
+// files below it. files maps slash separated paths, relative to the
+// workspace root, to file checksums; any checksum algorithm can be used.
+//
+// Digests form a Merkle tree: the digest of a directory covers the names
+// and checksums of its files and the names and digests of its
+// subdirectories, so it changes whenever anything below it changes. The
+// workspace root is reported as ".".
+func RollupChecksums(files map[string]string) map[string]string {
+	type entry struct{ name, kind, sum string }
+	children := make(map[string][]entry)
+	dirs := make(map[string]bool)
+	for p, sum := range files {
+		p = path.Clean(p)
+		dir := path.Dir(p)
+		children[dir] = append(children[dir], entry{path.Base(p), "f", sum})
+		for ; !dirs[dir]; dir = path.Dir(dir) {
+			dirs[dir] = true
+			if dir == "." {
+				break
+			}
+		}
+	}
+
+	// deeper directories first, so that subdirectory digests are known
+	// when their parent is computed
+	order := make([]string, 0, len(dirs))
+	for d := range dirs {
+		order = append(order, d)
+	}
+	sort.Slice(order, func(i, j int) bool {
+		di, dj := depth(order[i]), depth(order[j])
+		if di != dj {
+			return di > dj
+		}
+		return order[i] < order[j]
+	})
+
+	digests := make(map[string]string, len(order))
+	for _, d := range order {
+		entries := children[d]
+		sort.Slice(entries, func(i, j int) bool {
+			return entries[i].name < entries[j].name
+		})
+		h := sha256.New()
+		for _, e := range entries {
+			h.Write([]byte(e.kind + " " + e.name + " " + e.sum + "\n"))
+		}
+		digests[d] = hex.EncodeToString(h.Sum(nil))
+		if d != "." {
+			parent := path.Dir(d)
+			children[parent] = append(children[parent], entry{path.Base(d), "d", digests[d]})
+		}
+	}
+	return digests
+}
+
+func depth(dir string) int {
+	if dir == "." {
+		return 0
+	}
+	n := 1
+	for _, c := range dir {
+		if c == '/' {
+			n++
+		}
+	}
+	return n
+}
@@ -0,0 +1,163 @@
+package workspace
+
+import (
+	"bytes"
+	"encoding/hex"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+)
+
+// SymlinkPolicy controls how symbolic links are checksummed.
+type SymlinkPolicy int
+
+const (
+	// SymlinkTarget checksums the link target path rather than the content,
+	// so that links pointing outside the workspace don't depend on the
+	// runner. This is the default.
+	SymlinkTarget SymlinkPolicy = iota
+	// SymlinkFollow checksums the content of the linked file. Links to
+	// directories are not followed.
+	SymlinkFollow
+	// SymlinkSkip leaves symbolic links out.
+	SymlinkSkip
+)
+
+// BinaryPolicy controls whether binary files are checksummed.
+type BinaryPolicy int
+
+const (
+	// BinaryHash checksums binary files like any other file. This is the
+	// default.
+	BinaryHash BinaryPolicy = iota
+	// BinaryIgnore leaves binary files out.
+	BinaryIgnore
+)
+
+// binarySniffLen is the number of leading bytes inspected to detect binary
+// files.
+const binarySniffLen = 8000
+
+// WalkOptions configures Checksums. The zero value checksums symlink
+// targets, skips git submodules and hashes binary files with xxhash64.
+type WalkOptions struct {
+	Symlinks  SymlinkPolicy
+	Binary    BinaryPolicy
+	Algorithm ChecksumAlgorithm
+	// IncludeSubmodules descends into git submodules, whose checked out
+	// content depends on the runner's submodule setup.
+	IncludeSubmodules bool
+	// Exclude, if set, leaves out the files and directories it returns true
+	// for. Paths are slash separated and relative to the root.
+	Exclude func(path string, d fs.DirEntry) bool
+}
+
+// Checksums returns the checksum of every file below root, hex encoded and
+// keyed by slash separated path relative to root. The .git directory is
+// always skipped. The result can be passed to RollupChecksums.
+func Checksums(root string, opts WalkOptions) (map[string]string, error) {
+	if err := opts.Algorithm.Validate(); err != nil {
+		return nil, err
+	}
+	sums := make(map[string]string)
+	err := filepath.WalkDir(root, func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		rel, err := filepath.Rel(root, p)
+		if err != nil {
+			return err
+		}
+		rel = filepath.ToSlash(rel)
+		if rel == "." {
+			return nil
+		}
+		if d.IsDir() {
+			if d.Name() == ".git" {
+				return filepath.SkipDir
+			}
+			if !opts.IncludeSubmodules && isSubmodule(p) {
+				return filepath.SkipDir
+			}
+			if opts.Exclude != nil && opts.Exclude(rel, d) {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if opts.Exclude != nil && opts.Exclude(rel, d) {
+			return nil
+		}
+
+		var sum string
+		switch {
+		case d.Type()&fs.ModeSymlink != 0:
+			sum, err = symlinkChecksum(p, opts)
+		case d.Type().IsRegular():
+			sum, err = fileChecksum(p, opts.Binary, opts.Algorithm)
+		default:
+			// sockets, devices, ...
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		if sum != "" {
+			sums[rel] = sum
+		}
+		return nil
+	})
+	return sums, err
+}
+
+// isSubmodule reports whether dir is the root of a git submodule, which
+// has a .git file (not directory) pointing to the superproject.
+func isSubmodule(dir string) bool {
+	fi, err := os.Lstat(filepath.Join(dir, ".git"))
+	return err == nil && !fi.IsDir()
+}
+
+func symlinkChecksum(p string, opts WalkOptions) (string, error) {
+	switch opts.Symlinks {
+	case SymlinkSkip:
+		return "", nil
+	case SymlinkFollow:
+		fi, err := os.Stat(p)
+		if err != nil || !fi.Mode().IsRegular() {
+			// dangling links and links to directories are left out
+			return "", nil
+		}
+		return fileChecksum(p, opts.Binary, opts.Algorithm)
+	}
+	target, err := os.Readlink(p)
+	if err != nil {
+		return "", err
+	}
+	h := opts.Algorithm.newHash()
+	h.Write([]byte("symlink " + filepath.ToSlash(target)))
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
+func fileChecksum(p string, binary BinaryPolicy, algorithm ChecksumAlgorithm) (string, error) {
+	f, err := os.Open(p)
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+
+	head := make([]byte, binarySniffLen)
+	n, err := io.ReadFull(f, head)
+	if err != nil && err != io.EOF && err != io.ErrUnexpectedEOF {
+		return "", err
+	}
+	head = head[:n]
+	if binary == BinaryIgnore && bytes.IndexByte(head, 0) >= 0 {
+		return "", nil
+	}
+	h := algorithm.newHash()
+	h.Write(head)
+	if _, err := io.Copy(h, f); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
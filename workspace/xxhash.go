@@ -0,0 +1,127 @@
+package workspace
+
+import (
+	"encoding/binary"
+	"hash"
+	"math/bits"
+)
+
+// xxhash64 is a streaming implementation of the 64-bit xxHash (XXH64)
+// with seed 0, see https://github.com/Cyan4973/xxHash.
+type xxhash64 struct {
+	v1, v2, v3, v4 uint64
+	total          uint64
+	mem            [32]byte
+	n              int // bytes buffered in mem
+}
+
+const (
+	xxPrime1 uint64 = 11400714785074694791
+	xxPrime2 uint64 = 14029467366897019727
+	xxPrime3 uint64 = 1609587929392839161
+	xxPrime4 uint64 = 9650029242287828579
+	xxPrime5 uint64 = 2870177450012600261
+)
+
+func newXXHash64() hash.Hash64 {
+	h := &xxhash64{}
+	h.Reset()
+	return h
+}
+
+func (h *xxhash64) Reset() {
+	// the seeded accumulators wrap around, which constants can't
+	prime1, prime2 := xxPrime1, xxPrime2
+	h.v1 = prime1 + prime2
+	h.v2 = prime2
+	h.v3 = 0
+	h.v4 = -prime1
+	h.total, h.n = 0, 0
+}
+
+func (h *xxhash64) Size() int      { return 8 }
+func (h *xxhash64) BlockSize() int { return 32 }
+
+func (h *xxhash64) Write(p []byte) (int, error) {
+	n := len(p)
+	h.total += uint64(n)
+	if h.n+len(p) < 32 {
+		h.n += copy(h.mem[h.n:], p)
+		return n, nil
+	}
+	if h.n > 0 {
+		c := copy(h.mem[h.n:], p)
+		p = p[c:]
+		h.blocks(h.mem[:])
+		h.n = 0
+	}
+	if len(p) >= 32 {
+		full := len(p) &^ 31
+		h.blocks(p[:full])
+		p = p[full:]
+	}
+	h.n = copy(h.mem[:], p)
+	return n, nil
+}
+
+// blocks consumes p, whose length is a multiple of 32.
+func (h *xxhash64) blocks(p []byte) {
+	for ; len(p) >= 32; p = p[32:] {
+		h.v1 = xxRound(h.v1, binary.LittleEndian.Uint64(p[0:8]))
+		h.v2 = xxRound(h.v2, binary.LittleEndian.Uint64(p[8:16]))
+		h.v3 = xxRound(h.v3, binary.LittleEndian.Uint64(p[16:24]))
+		h.v4 = xxRound(h.v4, binary.LittleEndian.Uint64(p[24:32]))
+	}
+}
+
+func (h *xxhash64) Sum64() uint64 {
+	var acc uint64
+	if h.total >= 32 {
+		acc = bits.RotateLeft64(h.v1, 1) + bits.RotateLeft64(h.v2, 7) +
+			bits.RotateLeft64(h.v3, 12) + bits.RotateLeft64(h.v4, 18)
+		acc = xxMergeRound(acc, h.v1)
+		acc = xxMergeRound(acc, h.v2)
+		acc = xxMergeRound(acc, h.v3)
+		acc = xxMergeRound(acc, h.v4)
+	} else {
+		acc = xxPrime5
+	}
+	acc += h.total
+
+	p := h.mem[:h.n]
+	for ; len(p) >= 8; p = p[8:] {
+		acc ^= xxRound(0, binary.LittleEndian.Uint64(p))
+		acc = bits.RotateLeft64(acc, 27)*xxPrime1 + xxPrime4
+	}
+	if len(p) >= 4 {
+		acc ^= uint64(binary.LittleEndian.Uint32(p)) * xxPrime1
+		acc = bits.RotateLeft64(acc, 23)*xxPrime2 + xxPrime3
+		p = p[4:]
+	}
+	for _, b := range p {
+		acc ^= uint64(b) * xxPrime5
+		acc = bits.RotateLeft64(acc, 11) * xxPrime1
+	}
+
+	acc ^= acc >> 33
+	acc *= xxPrime2
+	acc ^= acc >> 29
+	acc *= xxPrime3
+	acc ^= acc >> 32
+	return acc
+}
+
+func (h *xxhash64) Sum(b []byte) []byte {
+	return binary.BigEndian.AppendUint64(b, h.Sum64())
+}
+
+func xxRound(acc, input uint64) uint64 {
+	acc += input * xxPrime2
+	acc = bits.RotateLeft64(acc, 31)
+	return acc * xxPrime1
+}
+
+func xxMergeRound(acc, v uint64) uint64 {
+	acc ^= xxRound(0, v)
+	return acc*xxPrime1 + xxPrime4
+}
@@ -0,0 +1,35 @@
+package workspace
+
+import (
+	"encoding/hex"
+	"testing"
+)
+
+func TestXXHash64(t *testing.T) {
+	tests := []struct {
+		in   string
+		want string
+	}{
+		{"", "ef46db3751d8e999"},
+		{"a", "d24ec4f1a98c6e5b"},
+		{"abc", "44bc2cf5ad770999"},
+		{"Nobody inspects the spammish repetition", "fbcea83c8a378bf1"},
+		{"0123456789abcdef0123456789abcdef0123456789", "a76190c3acf08a1c"},
+	}
+	for _, test := range tests {
+		h := newXXHash64()
+		h.Write([]byte(test.in))
+		if got := hex.EncodeToString(h.Sum(nil)); got != test.want {
+			t.Errorf("xxhash64(%q) = %s, want %s", test.in, got, test.want)
+		}
+		// the same digest must be computed when written byte by byte,
+		// going through the internal buffer
+		h.Reset()
+		for i := 0; i < len(test.in); i++ {
+			h.Write([]byte{test.in[i]})
+		}
+		if got := hex.EncodeToString(h.Sum(nil)); got != test.want {
+			t.Errorf("xxhash64(%q) written byte by byte = %s, want %s", test.in, got, test.want)
+		}
+	}
+}